@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -58,7 +59,7 @@ func main() {
 	}
 
 
-	res, _, err := client.Blocks.AppendBlockChildren(*blockID, params)
+	res, _, err := client.Blocks.AppendBlockChildren(context.Background(), *blockID, params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -25,9 +26,7 @@ func main() {
 	// Create a page in a database
 	// Sample command: go run create-page-example.go --access-token=<token> --page-id=<page-id> --db-id=<db-id>
 	params := &notion.CreatePageBodyParams{
-		Parent: &notion.DatabaseParent{
-			DatabaseID: *databaseID,
-		},
+		Parent: notion.NewDatabaseParent(*databaseID),
 		Properties: map[string]notion.PageProperty{
 			"Name": {
 				Title: []notion.RichText{
@@ -53,7 +52,7 @@ func main() {
 			},
 		},
 	}
-	resp, _, err := client.Pages.CreatePage(params)
+	resp, _, err := client.Pages.CreatePage(context.Background(), params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -63,9 +62,7 @@ func main() {
 
 	// Create a page within a page
 	params = &notion.CreatePageBodyParams{
-		Parent: &notion.PageParent{
-			PageID: *pageID,
-		},
+		Parent: notion.NewPageParent(*pageID),
 		Properties: &notion.PageProperty{
 			Title: []notion.RichText{
 				{
@@ -77,7 +74,7 @@ func main() {
 		},
 	}
 
-	resp, _, err = client.Pages.CreatePage(params)
+	resp, _, err = client.Pages.CreatePage(context.Background(), params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
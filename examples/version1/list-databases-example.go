@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -26,7 +27,7 @@ func main() {
 	params := &notion.ListDatabasesQueryParams{
 		PageSize: 20,
 	}
-	resp, _, err := client.Databases.ListDatabases(params)
+	resp, _, err := client.Databases.ListDatabases(context.Background(), params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
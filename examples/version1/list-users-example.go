@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -26,7 +27,7 @@ func main() {
 	params := &notion.ListUsersQueryParams{
 		PageSize: 20,
 	}
-	db, _, err := client.Users.ListUsers(params)
+	db, _, err := client.Users.ListUsers(context.Background(), params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
@@ -38,6 +39,6 @@ func main() {
 
 	// This code is sent in as a query param to the redirect_uri after the user has authorized notion
 	//<redirect_uri>?code=<code>&state=<state>
-	resp, _, _ := client.Auth.AccessToken(&c, *code)
+	resp, _, _ := client.Auth.AccessToken(context.Background(), &c, *code)
 	fmt.Println(resp)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"os"
 
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/filter"
 )
 
 func main() {
@@ -32,7 +34,7 @@ func main() {
 			},
 		},
 	}
-	resp, _, err := client.Databases.QueryDatabase(*databaseID, params)
+	resp, _, err := client.Databases.QueryDatabase(context.Background(), *databaseID, params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -55,13 +57,27 @@ func main() {
 				{
 					Property: "Recommended",
 					Checkbox: &notion.CheckboxCondition{
-						Equals: true,
+						Equals: notion.Bool(true),
 					},
 				},
 			},
 		},
 	}
-	resp, _, err = client.Databases.QueryDatabase(*databaseID, params)
+	resp, _, err = client.Databases.QueryDatabase(context.Background(), *databaseID, params)
+	if err != nil {
+		fmt.Printf("Err %v\n", err)
+	}
+
+	jsonBody, _ = json.Marshal(resp)
+	fmt.Println(string(jsonBody))
+
+	fmt.Println()
+
+	// Query DB with the same CompoundFilter, built with the filter package
+	params = &notion.QueryDatabaseBodyParams{
+		Filter: filter.MultiSelect("Tags").Contains("Tag1").And(filter.Checkbox("Recommended").Equals(true)),
+	}
+	resp, _, err = client.Databases.QueryDatabase(context.Background(), *databaseID, params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
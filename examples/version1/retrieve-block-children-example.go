@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -25,7 +26,7 @@ func main() {
 	// Retrieve the block children for a block
 	// Sample command: go run retrieve-block-children-example.go --access-token=<token> --block-id=<block-id>
 	params := &notion.RetrieveBlockChildrenParams{}
-	db, _, err := client.Blocks.RetrieveBlockChildren(*blockID, params)
+	db, _, err := client.Blocks.RetrieveBlockChildren(context.Background(), *blockID, params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -24,7 +25,7 @@ func main() {
 
 	// Retrieve DB
 	// Sample command: go run retrieve-database-example.go --access-token=<token> --db-id=<database-id>
-	db, _, err := client.Databases.RetrieveDatabase(*databaseID)
+	db, _, err := client.Databases.RetrieveDatabase(context.Background(), *databaseID)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -23,7 +24,7 @@ func main() {
 
 	// Retrieve a page using its pageID
 	// Sample command: go run retrieve-page-example.go --access-token=<token> --page-id=<page-id>
-	page, _, err := client.Pages.RetrievePage(*pageID)
+	page, _, err := client.Pages.RetrievePage(context.Background(), *pageID)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -24,7 +25,7 @@ func main() {
 
 	// Retrieve a user by userID
 	// Sample command: go run retrieve-user-example.go --access-token=<token> --user-id=<user-id>
-	db, _, err := client.Users.RetrieveUser(*userID)
+	db, _, err := client.Users.RetrieveUser(context.Background(), *userID)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
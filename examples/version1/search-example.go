@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -29,7 +30,7 @@ func main() {
 			Timestamp: "last_edited_time",
 		},
 	}
-	res, _, err := client.Search.SearchPage(params)
+	res, _, err := client.Search.SearchPage(context.Background(), params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
@@ -47,11 +48,31 @@ func main() {
 			Timestamp: "last_edited_time",
 		},
 	}
-	resII, _, err := client.Search.SearchDatabase(paramsII)
+	resII, _, err := client.Search.SearchDatabase(context.Background(), paramsII)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
 
 	jsonBodyII, _ := json.Marshal(resII)
 	fmt.Println(string(jsonBodyII))
+
+	fmt.Println("<==============================>")
+
+	// Search the workspace for pages and databases with titles that contain this
+	paramsIII := &notion.SearchBodyParams{
+		Query: "Yurts",
+	}
+	resIII, _, err := client.Search.Search(context.Background(), paramsIII)
+	if err != nil {
+		fmt.Printf("Err %v\n", err)
+	}
+
+	for _, result := range resIII.Results {
+		switch result.Object {
+		case "page":
+			fmt.Println("page:", result.Page.ID)
+		case "database":
+			fmt.Println("database:", result.Database.ID)
+		}
+	}
 }
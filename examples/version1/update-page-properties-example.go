@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -42,7 +43,7 @@ func main() {
 			},
 		},
 	}
-	resp, _, err := client.Pages.UpdatePageProperties(*pageID, params)
+	resp, _, err := client.Pages.UpdatePageProperties(context.Background(), *pageID, params)
 	if err != nil {
 		fmt.Printf("Err %v\n", err)
 	}
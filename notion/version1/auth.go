@@ -1,6 +1,7 @@
 package version1
 
 import (
+	"context"
 	"encoding/base64"
 	"github.com/dghubble/sling"
 	"golang.org/x/oauth2"
@@ -35,10 +36,10 @@ type AccessTokenResponse struct {
 	BotID         string `json:"bot_id"`
 }
 
-func (a *AuthService) AccessToken(c *oauth2.Config, authCode string) (*AccessTokenResponse, *http.Response, error) {
+func (a *AuthService) AccessToken(ctx context.Context, c *oauth2.Config,
+	authCode string) (*AccessTokenResponse, *http.Response, error) {
 
 	tokenResponse := new(AccessTokenResponse)
-	apiError := new(APIError)
 
 	tokenRequest := AccessTokenRequest{
 		GrantType:   "authorization_code",
@@ -46,10 +47,10 @@ func (a *AuthService) AccessToken(c *oauth2.Config, authCode string) (*AccessTok
 		RedirectURI: c.RedirectURL,
 	}
 
-	resp, err := a.sling.New().Post("token").
+	resp, err := receive(ctx, nonIdempotent.named("oauth.token"), a.sling.New().Post("token").
 		BodyJSON(tokenRequest).Add("Content-Type", "application/json").
-		Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.ClientID+":"+c.ClientSecret))).
-		Receive(tokenResponse, apiError)
+		Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.ClientID+":"+c.ClientSecret))),
+		tokenResponse)
 
-	return tokenResponse, resp, relevantError(err, *apiError)
+	return tokenResponse, resp, err
 }
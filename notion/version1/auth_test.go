@@ -1,6 +1,7 @@
 package version1_test
 
 import (
+	"context"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func TestAuthService_AccessToken(t *testing.T) {
 	}
 
 	client := notion.AuthClient(httpClient)
-	resp, _, err := client.Auth.AccessToken(&config, "1234")
+	resp, _, err := client.Auth.AccessToken(context.Background(), &config, "1234")
 	assert.Nil(t, err)
 	assert.Equal(t, &testAccessTokenRes, resp)
 }
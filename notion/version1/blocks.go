@@ -1,8 +1,11 @@
 package version1
 
 import (
+	"context"
+	"encoding/json"
 	"github.com/dghubble/sling"
 	"net/http"
+	"sync"
 )
 
 type BlockService struct {
@@ -19,7 +22,10 @@ func newBlockService(sling *sling.Sling) *BlockService {
 // https://developers.notion.com/reference/block
 // Object is always "block"
 // Type must be one of "paragraph", "heading_1", "heading_2", "heading_3", "bulleted_list_item",
-// "numbered_list_item", "to_do", "toggle", "child_page", and "unsupported"
+// "numbered_list_item", "to_do", "toggle", "child_page", "child_database", "code", "quote", "callout",
+// "divider", "image", "video", "file", "pdf", "bookmark", "embed", "equation", "table_of_contents", "breadcrumb",
+// "column_list", "column", "table", "table_row", "synced_block", "link_to_page", "template" and "unsupported".
+// Blocks of other types, and unsupported blocks, keep their content in Raw, and are sent back as they came.
 type Block struct {
 	Object           string                 `json:"object,omitempty"`
 	ID               string                 `json:"id,omitempty"`
@@ -36,6 +42,121 @@ type Block struct {
 	ToDo             *ToDoBlock             `json:"to_do,omitempty"`
 	Toggle           *ToggleBlock           `json:"toggle,omitempty"`
 	ChildPage        *ChildPageBlock        `json:"child_page,omitempty"`
+	ChildDatabase    *ChildDatabaseBlock    `json:"child_database,omitempty"`
+	Code             *CodeBlock             `json:"code,omitempty"`
+	Quote            *QuoteBlock            `json:"quote,omitempty"`
+	Callout          *CalloutBlock          `json:"callout,omitempty"`
+	Divider          *DividerBlock          `json:"divider,omitempty"`
+	Image            *FileBlock             `json:"image,omitempty"`
+	Video            *FileBlock             `json:"video,omitempty"`
+	File             *FileBlock             `json:"file,omitempty"`
+	PDF              *FileBlock             `json:"pdf,omitempty"`
+	Bookmark         *BookmarkBlock         `json:"bookmark,omitempty"`
+	Embed            *EmbedBlock            `json:"embed,omitempty"`
+	Equation         *Equation              `json:"equation,omitempty"`
+	TableOfContents  *TableOfContentsBlock  `json:"table_of_contents,omitempty"`
+	Breadcrumb       *BreadcrumbBlock       `json:"breadcrumb,omitempty"`
+	ColumnList       *ColumnListBlock       `json:"column_list,omitempty"`
+	Column           *ColumnBlock           `json:"column,omitempty"`
+	Table            *TableBlock            `json:"table,omitempty"`
+	TableRow         *TableRowBlock         `json:"table_row,omitempty"`
+	SyncedBlock      *SyncedBlock           `json:"synced_block,omitempty"`
+	LinkToPage       *LinkToPageBlock       `json:"link_to_page,omitempty"`
+	Template         *TemplateBlock         `json:"template,omitempty"`
+
+	// Raw is the content of a block whose type has no field above, as it was received.
+	Raw json.RawMessage `json:"-"`
+}
+
+// blockTypes are the types of the blocks with a field in Block.
+var blockTypes = map[string]bool{
+	"paragraph": true, "heading_1": true, "heading_2": true, "heading_3": true, "bulleted_list_item": true,
+	"numbered_list_item": true, "to_do": true, "toggle": true, "child_page": true, "child_database": true,
+	"code": true, "quote": true, "callout": true, "divider": true, "image": true, "video": true, "file": true,
+	"pdf": true, "bookmark": true, "embed": true, "equation": true, "table_of_contents": true, "breadcrumb": true,
+	"column_list": true, "column": true, "table": true, "table_row": true, "synced_block": true,
+	"link_to_page": true, "template": true,
+}
+
+func (b Block) MarshalJSON() ([]byte, error) {
+	type block Block
+	var fields []field
+	if b.Raw != nil && !blockTypes[b.Type] {
+		fields = append(fields, field{b.Type, b.Raw})
+	}
+	return marshalWithFields(block(b), fields...)
+}
+
+// UnmarshalJSON decodes the block, keeping the content of blocks of unknown types in Raw.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type block Block
+	var decoded block
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*b = Block(decoded)
+
+	if b.Type == "" || blockTypes[b.Type] {
+		return nil
+	}
+	var contents map[string]json.RawMessage
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return err
+	}
+	b.Raw = contents[b.Type]
+	return nil
+}
+
+// children returns the field of b's content that holds its children, or nil if blocks of its type can't hold
+// children.
+func (b *Block) children() *[]Block {
+	switch {
+	case b.Paragraph != nil:
+		return &b.Paragraph.Children
+	case b.BulletedListItem != nil:
+		return &b.BulletedListItem.Children
+	case b.NumberedListItem != nil:
+		return &b.NumberedListItem.Children
+	case b.ToDo != nil:
+		return &b.ToDo.Children
+	case b.Toggle != nil:
+		return &b.Toggle.Children
+	case b.Quote != nil:
+		return &b.Quote.Children
+	case b.Callout != nil:
+		return &b.Callout.Children
+	case b.ColumnList != nil:
+		return &b.ColumnList.Children
+	case b.Column != nil:
+		return &b.Column.Children
+	case b.Table != nil:
+		return &b.Table.Children
+	case b.SyncedBlock != nil:
+		return &b.SyncedBlock.Children
+	case b.Template != nil:
+		return &b.Template.Children
+	}
+	return nil
+}
+
+// Children returns the children held in the content of b, e.g. Paragraph.Children for a paragraph.
+// They are only set when b was built with children, or retrieved with RetrieveBlockTree.
+func (b *Block) Children() []Block {
+	if children := b.children(); children != nil {
+		return *children
+	}
+	return nil
+}
+
+// SetChildren sets the children held in the content of b. It returns false, and does nothing, if blocks of b's
+// type can't hold children, e.g. headings and child pages.
+func (b *Block) SetChildren(children []Block) bool {
+	field := b.children()
+	if field == nil {
+		return false
+	}
+	*field = children
+	return true
 }
 
 // https://developers.notion.com/reference/block#paragraph-blocks
@@ -89,6 +210,144 @@ type ChildPageBlock struct {
 	Title string `json:"title,omitempty"`
 }
 
+// https://developers.notion.com/reference/block#child-database-blocks
+type ChildDatabaseBlock struct {
+	Title string `json:"title,omitempty"`
+}
+
+// Language is the programming language of the code, e.g. "go" or "plain text".
+// https://developers.notion.com/reference/block#code-blocks
+type CodeBlock struct {
+	Text     []RichText `json:"text,omitempty"`
+	Caption  []RichText `json:"caption,omitempty"`
+	Language string     `json:"language,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#quote-blocks
+type QuoteBlock struct {
+	Text     []RichText `json:"text,omitempty"`
+	Children []Block    `json:"children,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#callout-blocks
+type CalloutBlock struct {
+	Text     []RichText `json:"text,omitempty"`
+	Icon     *Icon      `json:"icon,omitempty"`
+	Children []Block    `json:"children,omitempty"`
+}
+
+// Type is one of "emoji", "external" or "file", and the matching field is set.
+type Icon struct {
+	Type     string        `json:"type,omitempty"`
+	Emoji    string        `json:"emoji,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#divider-blocks
+type DividerBlock struct{}
+
+// FileBlock is the content of image, video, file and pdf blocks.
+// Type is "external" for files linked from elsewhere, or "file" for files uploaded to Notion.
+// https://developers.notion.com/reference/file-object
+type FileBlock struct {
+	Type     string        `json:"type,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+	Caption  []RichText    `json:"caption,omitempty"`
+}
+
+// URL returns the URL of the file, wherever it is hosted.
+func (f *FileBlock) URL() string {
+	switch {
+	case f.External != nil:
+		return f.External.URL
+	case f.File != nil:
+		return f.File.URL
+	}
+	return ""
+}
+
+type ExternalFile struct {
+	URL string `json:"url,omitempty"`
+}
+
+// URL is only valid until ExpiryTime.
+type HostedFile struct {
+	URL        string `json:"url,omitempty"`
+	ExpiryTime string `json:"expiry_time,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#bookmark-blocks
+type BookmarkBlock struct {
+	URL     string     `json:"url,omitempty"`
+	Caption []RichText `json:"caption,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#embed-blocks
+type EmbedBlock struct {
+	URL     string     `json:"url,omitempty"`
+	Caption []RichText `json:"caption,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#table-of-contents-blocks
+type TableOfContentsBlock struct{}
+
+// https://developers.notion.com/reference/block#breadcrumb-blocks
+type BreadcrumbBlock struct{}
+
+// Children must all be column blocks.
+// https://developers.notion.com/reference/block#column-list-and-column-blocks
+type ColumnListBlock struct {
+	Children []Block `json:"children,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#column-list-and-column-blocks
+type ColumnBlock struct {
+	Children []Block `json:"children,omitempty"`
+}
+
+// Children must all be table_row blocks with TableWidth cells.
+// https://developers.notion.com/reference/block#table-blocks
+type TableBlock struct {
+	TableWidth      int     `json:"table_width,omitempty"`
+	HasColumnHeader bool    `json:"has_column_header,omitempty"`
+	HasRowHeader    bool    `json:"has_row_header,omitempty"`
+	Children        []Block `json:"children,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#table-rows
+type TableRowBlock struct {
+	Cells [][]RichText `json:"cells,omitempty"`
+}
+
+// SyncedFrom is nil for an original synced block, and points to the original block for its copies.
+// https://developers.notion.com/reference/block#synced-block-blocks
+type SyncedBlock struct {
+	SyncedFrom *SyncedFrom `json:"synced_from"`
+	Children   []Block     `json:"children,omitempty"`
+}
+
+// Type is always "block_id"
+type SyncedFrom struct {
+	Type    string `json:"type,omitempty"`
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// Type is either "page_id" or "database_id", and the matching field is set.
+// https://developers.notion.com/reference/block#link-to-page-blocks
+type LinkToPageBlock struct {
+	Type       string `json:"type,omitempty"`
+	PageID     string `json:"page_id,omitempty"`
+	DatabaseID string `json:"database_id,omitempty"`
+}
+
+// https://developers.notion.com/reference/block#template-blocks
+type TemplateBlock struct {
+	Text     []RichText `json:"text,omitempty"`
+	Children []Block    `json:"children,omitempty"`
+}
+
 type RetrieveBlockChildrenParams struct {
 	StartCursor string `url:"start_cursor,omitempty"`
 	PageSize    int32  `url:"page_size,omitempty"`
@@ -102,13 +361,13 @@ type RetrieveBlockChildrenResponse struct {
 }
 
 // https://developers.notion.com/reference/get-block-children
-func (b *BlockService) RetrieveBlockChildren(blockID string,
+func (b *BlockService) RetrieveBlockChildren(ctx context.Context, blockID string,
 	params *RetrieveBlockChildrenParams) (*RetrieveBlockChildrenResponse, *http.Response, error) {
 	response := new(RetrieveBlockChildrenResponse)
-	apiError := new(APIError)
-	resp, err := b.sling.New().Get(blockID+"/children").QueryStruct(params).Receive(response, apiError)
+	resp, err := receive(ctx, idempotent.named("blocks.children.list").about(blockID),
+		b.sling.New().Get(blockID+"/children").QueryStruct(params), response)
 
-	return response, resp, relevantError(err, *apiError)
+	return response, resp, err
 }
 
 type AppendBlockChildrenBodyParams struct {
@@ -118,10 +377,138 @@ type AppendBlockChildrenBodyParams struct {
 // https://developers.notion.com/reference/patch-block-children
 // NB: Blocks cannot be modified currently. Once a block is appended as a child of another block,
 // it cannot be updated or deleted.
-func (b *BlockService) AppendBlockChildren(blockID string, params *AppendBlockChildrenBodyParams) (*Block, *http.Response, error) {
+func (b *BlockService) AppendBlockChildren(ctx context.Context, blockID string,
+	params *AppendBlockChildrenBodyParams) (*Block, *http.Response, error) {
 	block := new(Block)
-	apiError := new(APIError)
-	resp, err := b.sling.New().Patch(blockID+"/children").BodyJSON(params).Receive(block, apiError)
+	resp, err := receive(ctx, nonIdempotent.named("blocks.children.append").about(blockID),
+		b.sling.New().Patch(blockID+"/children").BodyJSON(params), block)
+
+	return block, resp, err
+}
+
+// ChildrenIter returns a BlockIter over every child of the block, starting at params.StartCursor.
+func (b *BlockService) ChildrenIter(ctx context.Context, blockID string, params *RetrieveBlockChildrenParams) *BlockIter {
+	query := RetrieveBlockChildrenParams{}
+	if params != nil {
+		query = *params
+	}
+
+	it := &BlockIter{}
+	it.iterator = newIterator(ctx, query.StartCursor, query.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			query.StartCursor, query.PageSize = cursor, pageSize
+			response, _, err := b.RetrieveBlockChildren(ctx, blockID, &query)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
+}
+
+// defaultTreeConcurrency is how many requests RetrieveBlockTree makes at once by default.
+const defaultTreeConcurrency = 3
+
+type BlockTreeOptions struct {
+	// MaxDepth limits how many levels of blocks are retrieved: 1 only retrieves the children of the block, 2
+	// their children too, and so on. 0 means no limit.
+	MaxDepth int
 
-	return block, resp, relevantError(err, *apiError)
+	// Concurrency is how many requests are made at once. Defaults to 3.
+	// Requests still wait for the client's RateLimiter, if it has one.
+	Concurrency int
+}
+
+// RetrieveBlockTree returns every block beneath the block or page with blockID, with the children of each block
+// set in its content (see Block.Children). Children are retrieved for every block with HasChildren that can hold
+// them, so the content of child pages and child databases is not retrieved.
+// If any request fails, the others are cancelled and its error is returned.
+func (b *BlockService) RetrieveBlockTree(ctx context.Context, blockID string, opts *BlockTreeOptions) ([]Block, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	t := &blockTree{service: b, cancel: cancel}
+	concurrency := defaultTreeConcurrency
+	if opts != nil {
+		t.maxDepth = opts.MaxDepth
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+	}
+	t.sem = make(chan struct{}, concurrency)
+
+	blocks, err := t.children(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	t.descend(ctx, blocks, 1)
+	t.wg.Wait()
+
+	if t.err != nil {
+		return nil, t.err
+	}
+	return blocks, nil
+}
+
+type blockTree struct {
+	service  *BlockService
+	maxDepth int
+	sem      chan struct{}
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// children returns every child of the block with blockID, once a request slot is free.
+func (t *blockTree) children(ctx context.Context, blockID string) ([]Block, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-t.sem }()
+
+	var blocks []Block
+	it := t.service.ChildrenIter(ctx, blockID, nil)
+	for it.Next() {
+		blocks = append(blocks, it.Value())
+	}
+	return blocks, it.Err()
+}
+
+// descend retrieves the children of blocks, which are at depth, in the background.
+func (t *blockTree) descend(ctx context.Context, blocks []Block, depth int) {
+	if t.maxDepth > 0 && depth >= t.maxDepth {
+		return
+	}
+
+	for i := range blocks {
+		block := &blocks[i]
+		if !block.HasChildren || block.children() == nil {
+			continue
+		}
+
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+
+			children, err := t.children(ctx, block.ID)
+			if err != nil {
+				t.fail(err)
+				return
+			}
+			block.SetChildren(children)
+			t.descend(ctx, children, depth+1)
+		}()
+	}
+}
+
+// fail records the first error and cancels the other requests.
+func (t *blockTree) fail(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		t.err = err
+		t.cancel()
+	}
 }
@@ -1,11 +1,15 @@
 package version1_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 )
 
 var (
@@ -83,7 +87,7 @@ func TestBlockService_RetrieveBlockChildren(t *testing.T) {
 
 	client := notion.NewClient(httpClient, "0000")
 	params := notion.RetrieveBlockChildrenParams{}
-	resp, _, err := client.Blocks.RetrieveBlockChildren("123", &params)
+	resp, _, err := client.Blocks.RetrieveBlockChildren(context.Background(), "123", &params)
 	assert.Nil(t, err)
 	assert.Equal(t, testRetrieveBlockChildrenRes, resp)
 }
@@ -100,7 +104,190 @@ func TestBlockService_AppendBlockChildren(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Blocks.AppendBlockChildren("123", testAppendBlockChildrenBodyParams)
+	resp, _, err := client.Blocks.AppendBlockChildren(context.Background(), "123", testAppendBlockChildrenBodyParams)
 	assert.Nil(t, err)
 	assert.Equal(t, testAppendBlockChildrenRes, resp)
 }
+
+var testAllBlocksJSON = `[
+	{"object":"block","id":"1","type":"code","code":{"text":[{"plain_text":"fmt.Println()"}],"language":"go"}},
+	{"object":"block","id":"2","type":"quote","quote":{"text":[{"plain_text":"Quoted"}]}},
+	{"object":"block","id":"3","type":"callout","callout":{"text":[{"plain_text":"Note"}],"icon":{"type":"emoji","emoji":"💡"}}},
+	{"object":"block","id":"4","type":"divider","divider":{}},
+	{"object":"block","id":"5","type":"image","image":{"type":"file","file":{"url":"https://s3/img.png","expiry_time":"2021-09-01T00:00:00.000Z"},"caption":[{"plain_text":"An image"}]}},
+	{"object":"block","id":"6","type":"pdf","pdf":{"type":"external","external":{"url":"https://example.com/a.pdf"}}},
+	{"object":"block","id":"7","type":"bookmark","bookmark":{"url":"https://example.com"}},
+	{"object":"block","id":"8","type":"equation","equation":{"expression":"e=mc^2"}},
+	{"object":"block","id":"9","type":"table_of_contents","table_of_contents":{}},
+	{"object":"block","id":"10","type":"column_list","has_children":true,"column_list":{}},
+	{"object":"block","id":"11","type":"table","has_children":true,"table":{"table_width":2,"has_column_header":true}},
+	{"object":"block","id":"12","type":"table_row","table_row":{"cells":[[{"plain_text":"a"}],[]]}},
+	{"object":"block","id":"13","type":"synced_block","synced_block":{"synced_from":{"type":"block_id","block_id":"1"}}},
+	{"object":"block","id":"14","type":"synced_block","synced_block":{"synced_from":null}},
+	{"object":"block","id":"15","type":"link_to_page","link_to_page":{"type":"page_id","page_id":"p1"}},
+	{"object":"block","id":"16","type":"child_database","child_database":{"title":"Tasks"}},
+	{"object":"block","id":"17","type":"unsupported","unsupported":{}},
+	{"object":"block","id":"18","type":"link_preview","link_preview":{"url":"https://github.com","extra":[1,{"a":null}]}}
+]`
+
+func TestBlock_JSON(t *testing.T) {
+	var blocks []notion.Block
+	err := json.Unmarshal([]byte(testAllBlocksJSON), &blocks)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "go", blocks[0].Code.Language)
+	assert.Equal(t, "💡", blocks[2].Callout.Icon.Emoji)
+	assert.Equal(t, &notion.DividerBlock{}, blocks[3].Divider)
+	assert.Equal(t, "https://s3/img.png", blocks[4].Image.URL())
+	assert.Equal(t, "https://example.com/a.pdf", blocks[5].PDF.URL())
+	assert.Equal(t, 2, blocks[10].Table.TableWidth)
+	assert.Equal(t, "a", blocks[11].TableRow.Cells[0][0].PlainText)
+	assert.Equal(t, "1", blocks[12].SyncedBlock.SyncedFrom.BlockID)
+	assert.Nil(t, blocks[13].SyncedBlock.SyncedFrom)
+	assert.Equal(t, json.RawMessage(`{}`), blocks[16].Raw)
+	assert.Equal(t, json.RawMessage(`{"url":"https://github.com","extra":[1,{"a":null}]}`), blocks[17].Raw)
+	assert.Nil(t, blocks[0].Raw)
+
+	// Nothing is lost on the way back, including the content of blocks of unknown types.
+	data, err := json.Marshal(blocks)
+	assert.Nil(t, err)
+	assert.JSONEq(t, testAllBlocksJSON, string(data))
+}
+
+var testBlockTreeJSON = map[string][]string{
+	"root": {
+		`{"object":"list","results":[{"object":"block","id":"p1","type":"paragraph","has_children":true,"paragraph":{}},` +
+			`{"object":"block","id":"t1","type":"toggle","has_children":true,"toggle":{}}],"next_cursor":null,"has_more":false}`,
+	},
+	"p1": {
+		`{"object":"list","results":[{"object":"block","id":"p1a","type":"paragraph","paragraph":{}}],"next_cursor":"c2","has_more":true}`,
+		`{"object":"list","results":[{"object":"block","id":"cp","type":"child_page","has_children":true,"child_page":{"title":"Sub"}}],"next_cursor":null,"has_more":false}`,
+	},
+	"t1": {
+		`{"object":"list","results":[{"object":"block","id":"td","type":"to_do","has_children":true,"to_do":{}}],"next_cursor":null,"has_more":false}`,
+	},
+	"td": {
+		`{"object":"list","results":[{"object":"block","id":"td1","type":"bulleted_list_item","bulleted_list_item":{}}],"next_cursor":null,"has_more":false}`,
+	},
+}
+
+// testBlockTreeServer serves the children in testBlockTreeJSON. handler, if set, is called with the ID of every
+// requested block and can make the request fail.
+func testBlockTreeServer(t *testing.T, handler func(id string) (ok bool)) (*http.Client, func()) {
+	httpClient, mux, server := testServer()
+
+	mux.HandleFunc("/v1/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		id := r.URL.Path[len("/v1/blocks/") : len(r.URL.Path)-len("/children")]
+		pages, ok := testBlockTreeJSON[id]
+		if handler != nil && !handler(id) {
+			ok = false
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, testNotFoundJSON)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("start_cursor") == "c2" {
+			fmt.Fprintf(w, pages[1])
+			return
+		}
+		fmt.Fprintf(w, pages[0])
+	})
+	return httpClient, server.Close
+}
+
+func blockIDs(blocks []notion.Block) []string {
+	var ids []string
+	for _, block := range blocks {
+		ids = append(ids, block.ID)
+	}
+	return ids
+}
+
+func TestBlockService_RetrieveBlockTree(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	httpClient, closeServer := testBlockTreeServer(t, func(id string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		requested = append(requested, id)
+		return true
+	})
+	defer closeServer()
+
+	client := notion.NewClient(httpClient, "0000")
+	blocks, err := client.Blocks.RetrieveBlockTree(context.Background(), "root", nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"p1", "t1"}, blockIDs(blocks))
+	assert.Equal(t, []string{"p1a", "cp"}, blockIDs(blocks[0].Children()))
+	assert.Equal(t, []string{"td"}, blockIDs(blocks[1].Toggle.Children))
+	assert.Equal(t, []string{"td1"}, blockIDs(blocks[1].Children()[0].Children()))
+	// The content of child pages isn't retrieved.
+	assert.ElementsMatch(t, []string{"root", "p1", "p1", "t1", "td"}, requested)
+}
+
+func TestBlockService_RetrieveBlockTree_MaxDepth(t *testing.T) {
+	httpClient, closeServer := testBlockTreeServer(t, nil)
+	defer closeServer()
+
+	client := notion.NewClient(httpClient, "0000")
+	blocks, err := client.Blocks.RetrieveBlockTree(context.Background(), "root", &notion.BlockTreeOptions{MaxDepth: 2})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"td"}, blockIDs(blocks[1].Children()))
+	assert.True(t, blocks[1].Children()[0].HasChildren)
+	assert.Nil(t, blocks[1].Children()[0].Children())
+}
+
+func TestBlockService_RetrieveBlockTree_Concurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	httpClient, closeServer := testBlockTreeServer(t, func(id string) bool {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return true
+	})
+	defer closeServer()
+
+	client := notion.NewClient(httpClient, "0000")
+	_, err := client.Blocks.RetrieveBlockTree(context.Background(), "root", &notion.BlockTreeOptions{Concurrency: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, maxInFlight)
+}
+
+func TestBlockService_RetrieveBlockTree_Error(t *testing.T) {
+	httpClient, closeServer := testBlockTreeServer(t, func(id string) bool {
+		return id != "td"
+	})
+	defer closeServer()
+
+	client := notion.NewClient(httpClient, "0000")
+	blocks, err := client.Blocks.RetrieveBlockTree(context.Background(), "root", nil)
+	assert.Nil(t, blocks)
+	assert.True(t, notion.IsNotFound(err))
+}
+
+func TestBlock_SetChildren(t *testing.T) {
+	child := notion.Block{Object: "block", Type: "paragraph", Paragraph: &notion.ParagraphBlock{}}
+
+	block := notion.Block{Type: "quote", Quote: &notion.QuoteBlock{}}
+	assert.True(t, block.SetChildren([]notion.Block{child}))
+	assert.Equal(t, []notion.Block{child}, block.Quote.Children)
+
+	heading := notion.Block{Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{}}
+	assert.False(t, heading.SetChildren([]notion.Block{child}))
+	assert.Nil(t, heading.Children())
+}
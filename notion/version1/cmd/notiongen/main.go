@@ -0,0 +1,70 @@
+// Command notiongen writes Go code for the pages of a Notion database: a row struct, constants for its
+// property names and select options, filter builders and functions to query, create and update rows.
+//
+// It reads the schema of the database with the API token in $NOTION_TOKEN, and is meant for go:generate:
+//
+//	//go:generate go run github.com/oyekanmiayo/go-notion/notion/version1/cmd/notiongen -database 668d797c76fa49349b05ad288df2d136 -type Task -o task_notion.go
+//
+// The package defaults to $GOPACKAGE, which go generate sets.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/gen"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		databaseID = flag.String("database", "", "ID of the database (required)")
+		typeName   = flag.String("type", "", "name of the row struct (default: the title of the database)")
+		pkg        = flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+		output     = flag.String("o", "", "file to write (default: standard output)")
+		timeout    = flag.Duration("timeout", 30*time.Second, "timeout of the request for the database")
+	)
+	flag.Parse()
+
+	if err := run(*databaseID, *typeName, *pkg, *output, *timeout); err != nil {
+		fmt.Fprintf(os.Stderr, "notiongen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(databaseID, typeName, pkg, output string, timeout time.Duration) error {
+	token := os.Getenv("NOTION_TOKEN")
+	switch {
+	case databaseID == "":
+		return errors.New("-database is required")
+	case pkg == "":
+		return errors.New("-package is required outside of go generate")
+	case token == "":
+		return errors.New("$NOTION_TOKEN is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := notion.NewClient(http.DefaultClient, token)
+	db, _, err := client.Databases.RetrieveDatabase(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+
+	src, err := gen.Generate(db, gen.Config{Package: pkg, TypeName: typeName})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(output, src, 0644)
+}
@@ -1,5 +1,10 @@
 package version1
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 // Type must be either "text", "mention" or "equation"
 // Use Mention for Mention
 type RichText struct {
@@ -58,3 +63,50 @@ type DatabaseMention struct {
 type Equation struct {
 	Expression string `json:"expression,omitempty"`
 }
+
+// Float64 returns a pointer to v, for number fields that are nil when empty.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Bool returns a pointer to v, for conditions that are nil when unset.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// field is a JSON object member added by marshalWithFields.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// marshalWithFields marshals v, a struct, and adds fields to the resulting object. It lets types keep omitempty
+// on their fields and still send empty values when they mean something, e.g. null to clear a number.
+func marshalWithFields(v interface{}, fields ...field) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, f := range fields {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
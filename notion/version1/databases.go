@@ -1,6 +1,7 @@
 package version1
 
 import (
+	"context"
 	"github.com/dghubble/sling"
 	"net/http"
 )
@@ -101,12 +102,12 @@ type RollupConfig struct {
 }
 
 // https://developers.notion.com/reference/get-database
-func (d *DatabaseService) RetrieveDatabase(databaseID string) (*Database, *http.Response, error) {
+func (d *DatabaseService) RetrieveDatabase(ctx context.Context, databaseID string) (*Database, *http.Response, error) {
 	database := new(Database)
-	apiError := new(APIError)
-	resp, err := d.sling.New().Get(databaseID).Receive(database, apiError)
+	resp, err := receive(ctx, idempotent.named("databases.retrieve").about(databaseID),
+		d.sling.New().Get(databaseID), database)
 
-	return database, resp, relevantError(err, *apiError)
+	return database, resp, err
 }
 
 // Filter can be either SingleFilter or CompoundFilter
@@ -126,6 +127,7 @@ type SortCriteria struct {
 }
 
 // OR and AND can either be []SingleFilter or []CompoundFilter (this is only valid once).
+// The filter package builds filters without having to fill these structs by hand.
 type CompoundFilter struct {
 	OR  interface{} `json:"or,omitempty"`
 	AND interface{} `json:"and,omitempty"`
@@ -145,6 +147,7 @@ type SingleFilter struct {
 	CreatedTime    *DateCondition        `json:"created_time,omitempty"`
 	LastEditedTime *DateCondition        `json:"last_edited_time,omitempty"`
 	Date           *DateCondition        `json:"date,omitempty"`
+	People         *PeopleCondition      `json:"people,omitempty"`
 	CreatedBy      *PeopleCondition      `json:"created_by,omitempty"`
 	LastEditedBy   *PeopleCondition      `json:"last_edited_by,omitempty"`
 	Files          *FileCondition        `json:"files,omitempty"`
@@ -160,39 +163,43 @@ type TextCondition struct {
 	StartsWith     string `json:"starts_with,omitempty"`
 	EndsWith       string `json:"ends_with,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
-	isNotEmpty     bool   `json:"is_not_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
+// Use Float64 to set the numbers, including to 0.
 type NumberCondition struct {
-	Equals               int32 `json:"equals,omitempty"`
-	DoesNotEqual         int32 `json:"does_not_equal,omitempty"`
-	GreaterThan          int32 `json:greater_than, omitempty`
-	LessThan             int32 `json:"less_than,omitempty"`
-	GreaterThanOrEqualTo int32 `json:"greater_than_or_equal_to,omitempty"`
-	LessThanOrEqualTo    int32 `json:"less_than_or_equal_to,omitempty"`
-	IsEmpty              bool  `json:"is_empty,omitempty"`
-	isNotEmpty           bool  `json:"is_not_empty,omitempty"`
+	Equals               *float64 `json:"equals,omitempty"`
+	DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+	GreaterThan          *float64 `json:"greater_than,omitempty"`
+	LessThan             *float64 `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
+	IsEmpty              bool     `json:"is_empty,omitempty"`
+	IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
 }
 
+// Use Bool to set Equals or DoesNotEqual, including to false.
 type CheckboxCondition struct {
-	Equals       bool `json:"equals,omitempty"`
-	DoesNotEqual bool `json:"does_not_equal,omitempty"`
+	Equals       *bool `json:"equals,omitempty"`
+	DoesNotEqual *bool `json:"does_not_equal,omitempty"`
 }
 
 type SelectCondition struct {
 	Equals       string `json:"equals,omitempty"`
 	DoesNotEqual string `json:"does_not_equal,omitempty"`
 	IsEmpty      bool   `json:"is_empty,omitempty"`
-	isNotEmpty   bool   `json:"is_not_empty,omitempty"`
+	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
 }
 
 type MultiSelectCondition struct {
 	Contains       string `json:"contains,omitempty"`
 	DoesNotContain string `json:"does_not_contain,omitempty"`
 	IsEmpty        bool   `json:"is_empty,omitempty"`
-	isNotEmpty     bool   `json:"is_not_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
+// Dates are ISO 8601 strings, e.g. "2021-05-10" or "2021-05-10T12:00:00Z".
+// PastWeek, PastMonth, PastYear, NextWeek, NextMonth and NextYear are set to an empty struct, e.g. struct{}{}.
 type DateCondition struct {
 	Equals     string      `json:"equals,omitempty"`
 	Before     string      `json:"before,omitempty"`
@@ -222,10 +229,10 @@ type FileCondition struct {
 }
 
 type RelationCondition struct {
-	Contains        string `json:"contains,omitempty"`
-	DoesNotContains string `json:"does_not_contains,omitempty"`
-	IsEmpty         string `json:"is_empty,omitempty"`
-	IsNotEmpty      string `json:"is_not_empty,omitempty"`
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
 type FormulaCondition struct {
@@ -243,14 +250,14 @@ type QueryDatabaseResponse struct {
 }
 
 // https://developers.notion.com/reference/post-database-query
-func (d *DatabaseService) QueryDatabase(databaseID string,
+func (d *DatabaseService) QueryDatabase(ctx context.Context, databaseID string,
 	params *QueryDatabaseBodyParams) (*QueryDatabaseResponse, *http.Response, error) {
 
 	response := new(QueryDatabaseResponse)
-	apiError := new(APIError)
-	resp, err := d.sling.New().Post(databaseID+"/query").BodyJSON(params).Receive(response, apiError)
+	resp, err := receive(ctx, idempotent.named("databases.query").about(databaseID),
+		d.sling.New().Post(databaseID+"/query").BodyJSON(params), response)
 
-	return response, resp, relevantError(err, *apiError)
+	return response, resp, err
 }
 
 type ListDatabasesQueryParams struct {
@@ -265,10 +272,48 @@ type ListDatabasesResponse struct {
 }
 
 // https://developers.notion.com/reference/get-databases
-func (d *DatabaseService) ListDatabases(params *ListDatabasesQueryParams) (*ListDatabasesResponse, *http.Response, error) {
+func (d *DatabaseService) ListDatabases(ctx context.Context,
+	params *ListDatabasesQueryParams) (*ListDatabasesResponse, *http.Response, error) {
 	response := new(ListDatabasesResponse)
-	apiError := new(APIError)
-	resp, err := d.sling.New().Get("").QueryStruct(params).Receive(response, apiError)
+	resp, err := receive(ctx, idempotent.named("databases.list"), d.sling.New().Get("").QueryStruct(params), response)
+
+	return response, resp, err
+}
+
+// QueryIter returns a PageIter over every page of the database that matches params, starting at
+// params.StartCursor.
+func (d *DatabaseService) QueryIter(ctx context.Context, databaseID string, params *QueryDatabaseBodyParams) *PageIter {
+	body := QueryDatabaseBodyParams{}
+	if params != nil {
+		body = *params
+	}
+
+	it := &PageIter{}
+	it.iterator = newIterator(ctx, body.StartCursor, body.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			body.StartCursor, body.PageSize = cursor, pageSize
+			response, _, err := d.QueryDatabase(ctx, databaseID, &body)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
+}
+
+// ListIter returns a DatabaseIter over every database shared with the integration, starting at
+// params.StartCursor.
+func (d *DatabaseService) ListIter(ctx context.Context, params *ListDatabasesQueryParams) *DatabaseIter {
+	query := ListDatabasesQueryParams{}
+	if params != nil {
+		query = *params
+	}
 
-	return response, resp, relevantError(err, *apiError)
+	it := &DatabaseIter{}
+	it.iterator = newIterator(ctx, query.StartCursor, query.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			query.StartCursor, query.PageSize = cursor, pageSize
+			response, _, err := d.ListDatabases(ctx, &query)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
 }
@@ -1,6 +1,8 @@
 package version1_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
@@ -56,12 +58,7 @@ var (
 				ID:             "5678",
 				CreatedTime:    "2021-05-14T01:06:32.845Z",
 				LastEditedTime: "2021-05-23T08:02:00.000Z",
-				// Technically this is &DatabaseParent but Go will see type as map[string]interface{}
-				// Haven't found a workaround yet :)
-				Parent: map[string]interface{}{
-					"database_id": "38923",
-					"type":        "database_id",
-				},
+				Parent:         notion.NewDatabaseParent("38923"),
 				Properties: map[string]notion.PageProperty{
 					"Name": {
 						ID:   "title",
@@ -162,7 +159,7 @@ func TestDatabaseService_RetrieveDatabase(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Databases.RetrieveDatabase("123")
+	resp, _, err := client.Databases.RetrieveDatabase(context.Background(), "123")
 	assert.Nil(t, err)
 	assert.Equal(t, testDatabaseRes, resp)
 }
@@ -179,7 +176,7 @@ func TestDatabaseService_QueryDatabase_SingleFilter(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Databases.QueryDatabase("123", testSingleFilterParams)
+	resp, _, err := client.Databases.QueryDatabase(context.Background(), "123", testSingleFilterParams)
 	assert.Nil(t, err)
 	assert.Equal(t, testFilterRes, resp)
 
@@ -197,7 +194,7 @@ func TestDatabaseService_QueryDatabase_CompoundFilter(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Databases.QueryDatabase("123", testCompFilterParams)
+	resp, _, err := client.Databases.QueryDatabase(context.Background(), "123", testCompFilterParams)
 	assert.Nil(t, err)
 	assert.Equal(t, testFilterRes, resp)
 
@@ -214,7 +211,21 @@ func TestDatabaseService_ListDatabases(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Databases.ListDatabases(&notion.ListDatabasesQueryParams{})
+	resp, _, err := client.Databases.ListDatabases(context.Background(), &notion.ListDatabasesQueryParams{})
 	assert.Nil(t, err)
 	assert.Equal(t, testListDBRes, resp)
 }
+
+func TestNumberCondition_JSON(t *testing.T) {
+	filter := &notion.SingleFilter{
+		Property: "Price",
+		Number: &notion.NumberCondition{
+			GreaterThan: notion.Float64(0),
+			LessThan:    notion.Float64(9.99),
+		},
+	}
+
+	data, err := json.Marshal(filter)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"property":"Price","number":{"greater_than":0,"less_than":9.99}}`, string(data))
+}
@@ -1,32 +1,136 @@
 package version1
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // APIError represents a Notion API response
 // https://developers.notion.com/reference/errors
 // Object is always "error"
+// Status, RequestID and RetryAfter are filled from the HTTP response when the body doesn't carry them.
 type APIError struct {
-	Object  string `json:"object,omitempty"`
-	Status  int32  `json:"status,omitempty"`
-	Code    string `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
+	Object     string        `json:"object,omitempty"`
+	Status     int32         `json:"status,omitempty"`
+	Code       string        `json:"code,omitempty"`
+	Message    string        `json:"message,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e APIError) Error() string {
 	return fmt.Sprintf("code: %v, message: %v", e.Code, e.Message)
 }
 
+// Sentinel errors matched by APIError through errors.Is, e.g. errors.Is(err, ErrNotFound).
+// https://developers.notion.com/reference/errors
+var (
+	ErrNotFound        = errors.New("notion: object not found")
+	ErrRateLimited     = errors.New("notion: rate limited")
+	ErrUnauthorized    = errors.New("notion: unauthorized")
+	ErrValidationError = errors.New("notion: validation error")
+	ErrConflict        = errors.New("notion: conflict")
+	ErrRestricted      = errors.New("notion: restricted resource")
+)
+
+// errorCodes maps Notion error codes to their sentinel error.
+var errorCodes = map[string]error{
+	"object_not_found":    ErrNotFound,
+	"rate_limited":        ErrRateLimited,
+	"unauthorized":        ErrUnauthorized,
+	"validation_error":    ErrValidationError,
+	"conflict_error":      ErrConflict,
+	"restricted_resource": ErrRestricted,
+}
+
+// errorStatuses maps HTTP statuses to a sentinel error, for errors whose code is missing or not in errorCodes, e.g.
+// 400 invalid_json.
+var errorStatuses = map[int32]error{
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusTooManyRequests: ErrRateLimited,
+	http.StatusUnauthorized:    ErrUnauthorized,
+	http.StatusBadRequest:      ErrValidationError,
+	http.StatusConflict:        ErrConflict,
+	http.StatusForbidden:       ErrRestricted,
+}
+
+// Is reports whether e is classified as target, one of the sentinel errors above, by its code or else its status.
+func (e APIError) Is(target error) bool {
+	if err, ok := errorCodes[e.Code]; ok {
+		return err == target
+	}
+	return errorStatuses[e.Status] == target
+}
+
+// IsNotFound reports whether err means the requested object doesn't exist or isn't shared with the integration.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited reports whether err means the integration made too many requests.
+// See APIError.RetryAfter for how long Notion asked to wait.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsUnauthorized reports whether err means the bearer token is invalid.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsValidationError reports whether err means the request body or parameters were rejected.
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidationError)
+}
+
+// IsConflict reports whether err means the transaction could not be completed because of a data collision.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRestricted reports whether err means the integration lacks the capability to perform the operation.
+func IsRestricted(err error) bool {
+	return errors.Is(err, ErrRestricted)
+}
+
 // relevantError returns any http-related error if it exists
-// if http-related errors don't exist, it returns apiError if it exists
+// if the request failed because ctx was cancelled or its deadline passed, it returns ctx.Err()
+// so callers can tell it apart from other failures with errors.Is
+// if the response is not a 2XX, it returns apiError, completed with details from the response
 // else it returns nil
-func relevantError(httpError error, apiError APIError) error {
+func relevantError(ctx context.Context, resp *http.Response, httpError error, apiError APIError) error {
 	if httpError != nil {
-		return httpError
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if resp == nil || isSuccess(resp.StatusCode) {
+			return httpError
+		}
+		// The error body couldn't be decoded, so rely on the response alone.
+		apiError = APIError{Message: http.StatusText(resp.StatusCode)}
 	}
 
-	if (APIError{}) == apiError {
+	if resp == nil || isSuccess(resp.StatusCode) {
 		return nil
 	}
 
+	if apiError.Object == "" {
+		apiError.Object = "error"
+	}
+	if apiError.Status == 0 {
+		apiError.Status = int32(resp.StatusCode)
+	}
+	if apiError.RequestID == "" {
+		apiError.RequestID = resp.Header.Get("X-Notion-Request-Id")
+	}
+	apiError.RetryAfter, _ = retryAfter(resp)
+
 	return apiError
 }
+
+func isSuccess(status int) bool {
+	return 200 <= status && status <= 299
+}
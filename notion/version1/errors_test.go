@@ -0,0 +1,108 @@
+package version1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var (
+	testNotFoundJSON = `{"object":"error","status":404,"code":"object_not_found","message":"Could not find database with ID: 123."}`
+	testNotFound     = notion.APIError{
+		Object:    "error",
+		Status:    404,
+		Code:      "object_not_found",
+		Message:   "Could not find database with ID: 123.",
+		RequestID: "req-1",
+	}
+)
+
+func TestAPIError_NotFound(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/databases/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Notion-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, testNotFoundJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Databases.RetrieveDatabase(context.Background(), "123")
+	assert.True(t, notion.IsNotFound(err))
+	assert.True(t, errors.Is(err, notion.ErrNotFound))
+	assert.False(t, notion.IsRateLimited(err))
+
+	var apiError notion.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, testNotFound, apiError)
+}
+
+func TestAPIError_RateLimited(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, testRateLimitedJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.True(t, notion.IsRateLimited(err))
+
+	var apiError notion.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, 7*time.Second, apiError.RetryAfter)
+}
+
+func TestAPIError_UndecodableBody(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/pages/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, "<html>Unauthorized</html>")
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Pages.RetrievePage(context.Background(), "123")
+	assert.True(t, notion.IsUnauthorized(err))
+
+	var apiError notion.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, int32(http.StatusUnauthorized), apiError.Status)
+}
+
+func TestAPIError_Is(t *testing.T) {
+	cases := []struct {
+		err    notion.APIError
+		target error
+	}{
+		{notion.APIError{Code: "validation_error", Status: 400}, notion.ErrValidationError},
+		{notion.APIError{Code: "conflict_error", Status: 409}, notion.ErrConflict},
+		{notion.APIError{Code: "restricted_resource", Status: 403}, notion.ErrRestricted},
+		{notion.APIError{Status: 409}, notion.ErrConflict},
+		// Codes without a sentinel error of their own are classified by their status.
+		{notion.APIError{Code: "invalid_json", Status: 400}, notion.ErrValidationError},
+		{notion.APIError{Code: "invalid_request", Status: 400}, notion.ErrValidationError},
+		{notion.APIError{Code: "missing_version", Status: 400}, notion.ErrValidationError},
+		{notion.APIError{Code: "invalid_request_url", Status: 400}, notion.ErrValidationError},
+	}
+	for _, c := range cases {
+		assert.True(t, errors.Is(c.err, c.target), c.err.Code)
+	}
+
+	// A known code takes precedence over the status.
+	assert.False(t, errors.Is(notion.APIError{Code: "rate_limited", Status: 400}, notion.ErrValidationError))
+	assert.False(t, errors.Is(notion.APIError{Code: "internal_server_error", Status: 500}, notion.ErrValidationError))
+}
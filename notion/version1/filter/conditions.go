@@ -0,0 +1,469 @@
+package filter
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+)
+
+// TextFilter builds conditions on title, rich_text, url, email and phone properties.
+type TextFilter struct {
+	property string
+	set      func(*notion.SingleFilter, *notion.TextCondition)
+}
+
+// Title filters on a title property.
+func Title(property string) TextFilter {
+	return TextFilter{property, func(f *notion.SingleFilter, c *notion.TextCondition) { f.Title = c }}
+}
+
+// Text filters on a rich_text property.
+func Text(property string) TextFilter {
+	return TextFilter{property, func(f *notion.SingleFilter, c *notion.TextCondition) { f.RichText = c }}
+}
+
+// URL filters on a url property.
+func URL(property string) TextFilter {
+	return TextFilter{property, func(f *notion.SingleFilter, c *notion.TextCondition) { f.URL = c }}
+}
+
+// Email filters on an email property.
+func Email(property string) TextFilter {
+	return TextFilter{property, func(f *notion.SingleFilter, c *notion.TextCondition) { f.Email = c }}
+}
+
+// Phone filters on a phone_number property.
+func Phone(property string) TextFilter {
+	return TextFilter{property, func(f *notion.SingleFilter, c *notion.TextCondition) { f.Phone = c }}
+}
+
+func (t TextFilter) condition(c *notion.TextCondition) *Filter {
+	return single(t.property, func(f *notion.SingleFilter) { t.set(f, c) })
+}
+
+// Equals matches text equal to value. Equals("") matches empty text.
+func (t TextFilter) Equals(value string) *Filter {
+	if value == "" {
+		return t.IsEmpty()
+	}
+	return t.condition(&notion.TextCondition{Equals: value})
+}
+
+// DoesNotEqual matches text different from value. DoesNotEqual("") matches text that isn't empty.
+func (t TextFilter) DoesNotEqual(value string) *Filter {
+	if value == "" {
+		return t.IsNotEmpty()
+	}
+	return t.condition(&notion.TextCondition{DoesNotEqual: value})
+}
+
+// Contains matches text that contains value. value must not be empty: the condition would be sent without it,
+// which Notion rejects.
+func (t TextFilter) Contains(value string) *Filter {
+	return t.condition(&notion.TextCondition{Contains: value})
+}
+
+// DoesNotContain matches text that doesn't contain value. value must not be empty, see Contains.
+func (t TextFilter) DoesNotContain(value string) *Filter {
+	return t.condition(&notion.TextCondition{DoesNotContain: value})
+}
+
+// StartsWith matches text that starts with value. value must not be empty, see Contains.
+func (t TextFilter) StartsWith(value string) *Filter {
+	return t.condition(&notion.TextCondition{StartsWith: value})
+}
+
+// EndsWith matches text that ends with value. value must not be empty, see Contains.
+func (t TextFilter) EndsWith(value string) *Filter {
+	return t.condition(&notion.TextCondition{EndsWith: value})
+}
+
+// IsEmpty matches empty text.
+func (t TextFilter) IsEmpty() *Filter {
+	return t.condition(&notion.TextCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches text that isn't empty.
+func (t TextFilter) IsNotEmpty() *Filter {
+	return t.condition(&notion.TextCondition{IsNotEmpty: true})
+}
+
+// NumberFilter builds conditions on number properties.
+type NumberFilter struct {
+	property string
+	set      func(*notion.SingleFilter, *notion.NumberCondition)
+}
+
+// Number filters on a number property.
+func Number(property string) NumberFilter {
+	return NumberFilter{property, func(f *notion.SingleFilter, c *notion.NumberCondition) { f.Number = c }}
+}
+
+func (n NumberFilter) condition(c *notion.NumberCondition) *Filter {
+	return single(n.property, func(f *notion.SingleFilter) { n.set(f, c) })
+}
+
+// Equals matches numbers equal to value.
+func (n NumberFilter) Equals(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{Equals: notion.Float64(value)})
+}
+
+// DoesNotEqual matches numbers different from value.
+func (n NumberFilter) DoesNotEqual(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{DoesNotEqual: notion.Float64(value)})
+}
+
+// GreaterThan matches numbers greater than value.
+func (n NumberFilter) GreaterThan(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{GreaterThan: notion.Float64(value)})
+}
+
+// LessThan matches numbers less than value.
+func (n NumberFilter) LessThan(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{LessThan: notion.Float64(value)})
+}
+
+// GreaterThanOrEqualTo matches numbers greater than or equal to value.
+func (n NumberFilter) GreaterThanOrEqualTo(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{GreaterThanOrEqualTo: notion.Float64(value)})
+}
+
+// LessThanOrEqualTo matches numbers less than or equal to value.
+func (n NumberFilter) LessThanOrEqualTo(value float64) *Filter {
+	return n.condition(&notion.NumberCondition{LessThanOrEqualTo: notion.Float64(value)})
+}
+
+// IsEmpty matches empty numbers.
+func (n NumberFilter) IsEmpty() *Filter {
+	return n.condition(&notion.NumberCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches numbers that aren't empty.
+func (n NumberFilter) IsNotEmpty() *Filter {
+	return n.condition(&notion.NumberCondition{IsNotEmpty: true})
+}
+
+// CheckboxFilter builds conditions on checkbox properties.
+type CheckboxFilter struct {
+	property string
+	set      func(*notion.SingleFilter, *notion.CheckboxCondition)
+}
+
+// Checkbox filters on a checkbox property.
+func Checkbox(property string) CheckboxFilter {
+	return CheckboxFilter{property, func(f *notion.SingleFilter, c *notion.CheckboxCondition) { f.Checkbox = c }}
+}
+
+func (c CheckboxFilter) condition(cond *notion.CheckboxCondition) *Filter {
+	return single(c.property, func(f *notion.SingleFilter) { c.set(f, cond) })
+}
+
+// Equals matches checkboxes that are value.
+func (c CheckboxFilter) Equals(value bool) *Filter {
+	return c.condition(&notion.CheckboxCondition{Equals: notion.Bool(value)})
+}
+
+// DoesNotEqual matches checkboxes that aren't value.
+func (c CheckboxFilter) DoesNotEqual(value bool) *Filter {
+	return c.condition(&notion.CheckboxCondition{DoesNotEqual: notion.Bool(value)})
+}
+
+// SelectFilter builds conditions on select properties.
+type SelectFilter struct {
+	property string
+}
+
+// Select filters on a select property.
+func Select(property string) SelectFilter {
+	return SelectFilter{property}
+}
+
+func (s SelectFilter) condition(c *notion.SelectCondition) *Filter {
+	return single(s.property, func(f *notion.SingleFilter) { f.Select = c })
+}
+
+// Equals matches the option named value. Equals("") matches an empty select.
+func (s SelectFilter) Equals(value string) *Filter {
+	if value == "" {
+		return s.IsEmpty()
+	}
+	return s.condition(&notion.SelectCondition{Equals: value})
+}
+
+// DoesNotEqual matches any option other than value. DoesNotEqual("") matches a select that isn't empty.
+func (s SelectFilter) DoesNotEqual(value string) *Filter {
+	if value == "" {
+		return s.IsNotEmpty()
+	}
+	return s.condition(&notion.SelectCondition{DoesNotEqual: value})
+}
+
+// IsEmpty matches an empty select.
+func (s SelectFilter) IsEmpty() *Filter {
+	return s.condition(&notion.SelectCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches a select that isn't empty.
+func (s SelectFilter) IsNotEmpty() *Filter {
+	return s.condition(&notion.SelectCondition{IsNotEmpty: true})
+}
+
+// MultiSelectFilter builds conditions on multi_select properties.
+type MultiSelectFilter struct {
+	property string
+}
+
+// MultiSelect filters on a multi_select property.
+func MultiSelect(property string) MultiSelectFilter {
+	return MultiSelectFilter{property}
+}
+
+func (m MultiSelectFilter) condition(c *notion.MultiSelectCondition) *Filter {
+	return single(m.property, func(f *notion.SingleFilter) { f.MultiSelect = c })
+}
+
+// Contains matches multi-selects that include the option named value.
+func (m MultiSelectFilter) Contains(value string) *Filter {
+	return m.condition(&notion.MultiSelectCondition{Contains: value})
+}
+
+// DoesNotContain matches multi-selects that don't include the option named value.
+func (m MultiSelectFilter) DoesNotContain(value string) *Filter {
+	return m.condition(&notion.MultiSelectCondition{DoesNotContain: value})
+}
+
+// IsEmpty matches multi-selects without options.
+func (m MultiSelectFilter) IsEmpty() *Filter {
+	return m.condition(&notion.MultiSelectCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches multi-selects with at least one option.
+func (m MultiSelectFilter) IsNotEmpty() *Filter {
+	return m.condition(&notion.MultiSelectCondition{IsNotEmpty: true})
+}
+
+// DateFilter builds conditions on date, created_time and last_edited_time properties.
+// Dates are ISO 8601 strings, e.g. "2021-05-10" or "2021-05-10T12:00:00Z".
+type DateFilter struct {
+	property string
+	set      func(*notion.SingleFilter, *notion.DateCondition)
+}
+
+// Date filters on a date property.
+func Date(property string) DateFilter {
+	return DateFilter{property, func(f *notion.SingleFilter, c *notion.DateCondition) { f.Date = c }}
+}
+
+// CreatedTime filters on a created_time property.
+func CreatedTime(property string) DateFilter {
+	return DateFilter{property, func(f *notion.SingleFilter, c *notion.DateCondition) { f.CreatedTime = c }}
+}
+
+// LastEditedTime filters on a last_edited_time property.
+func LastEditedTime(property string) DateFilter {
+	return DateFilter{property, func(f *notion.SingleFilter, c *notion.DateCondition) { f.LastEditedTime = c }}
+}
+
+func (d DateFilter) condition(c *notion.DateCondition) *Filter {
+	return single(d.property, func(f *notion.SingleFilter) { d.set(f, c) })
+}
+
+// Equals matches dates equal to date.
+func (d DateFilter) Equals(date string) *Filter {
+	return d.condition(&notion.DateCondition{Equals: date})
+}
+
+// Before matches dates before date.
+func (d DateFilter) Before(date string) *Filter {
+	return d.condition(&notion.DateCondition{Before: date})
+}
+
+// After matches dates after date.
+func (d DateFilter) After(date string) *Filter {
+	return d.condition(&notion.DateCondition{After: date})
+}
+
+// OnOrBefore matches dates on or before date.
+func (d DateFilter) OnOrBefore(date string) *Filter {
+	return d.condition(&notion.DateCondition{OnOrBefore: date})
+}
+
+// OnOrAfter matches dates on or after date.
+func (d DateFilter) OnOrAfter(date string) *Filter {
+	return d.condition(&notion.DateCondition{OnOrAfter: date})
+}
+
+// IsEmpty matches empty dates.
+func (d DateFilter) IsEmpty() *Filter {
+	return d.condition(&notion.DateCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches dates that aren't empty.
+func (d DateFilter) IsNotEmpty() *Filter {
+	return d.condition(&notion.DateCondition{IsNotEmpty: true})
+}
+
+// PastWeek matches dates within the past week.
+func (d DateFilter) PastWeek() *Filter {
+	return d.condition(&notion.DateCondition{PastWeek: empty})
+}
+
+// PastMonth matches dates within the past month.
+func (d DateFilter) PastMonth() *Filter {
+	return d.condition(&notion.DateCondition{PastMonth: empty})
+}
+
+// PastYear matches dates within the past year.
+func (d DateFilter) PastYear() *Filter {
+	return d.condition(&notion.DateCondition{PastYear: empty})
+}
+
+// NextWeek matches dates within the next week.
+func (d DateFilter) NextWeek() *Filter {
+	return d.condition(&notion.DateCondition{NextWeek: empty})
+}
+
+// NextMonth matches dates within the next month.
+func (d DateFilter) NextMonth() *Filter {
+	return d.condition(&notion.DateCondition{NextMonth: empty})
+}
+
+// NextYear matches dates within the next year.
+func (d DateFilter) NextYear() *Filter {
+	return d.condition(&notion.DateCondition{NextYear: empty})
+}
+
+// PeopleFilter builds conditions on people, created_by and last_edited_by properties.
+type PeopleFilter struct {
+	property string
+	set      func(*notion.SingleFilter, *notion.PeopleCondition)
+}
+
+// People filters on a people property.
+func People(property string) PeopleFilter {
+	return PeopleFilter{property, func(f *notion.SingleFilter, c *notion.PeopleCondition) { f.People = c }}
+}
+
+// CreatedBy filters on a created_by property.
+func CreatedBy(property string) PeopleFilter {
+	return PeopleFilter{property, func(f *notion.SingleFilter, c *notion.PeopleCondition) { f.CreatedBy = c }}
+}
+
+// LastEditedBy filters on a last_edited_by property.
+func LastEditedBy(property string) PeopleFilter {
+	return PeopleFilter{property, func(f *notion.SingleFilter, c *notion.PeopleCondition) { f.LastEditedBy = c }}
+}
+
+func (p PeopleFilter) condition(c *notion.PeopleCondition) *Filter {
+	return single(p.property, func(f *notion.SingleFilter) { p.set(f, c) })
+}
+
+// Contains matches people that include the user with userID.
+func (p PeopleFilter) Contains(userID string) *Filter {
+	return p.condition(&notion.PeopleCondition{Contains: userID})
+}
+
+// DoesNotContain matches people that don't include the user with userID.
+func (p PeopleFilter) DoesNotContain(userID string) *Filter {
+	return p.condition(&notion.PeopleCondition{DoesNotContain: userID})
+}
+
+// IsEmpty matches properties without people.
+func (p PeopleFilter) IsEmpty() *Filter {
+	return p.condition(&notion.PeopleCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches properties with at least one person.
+func (p PeopleFilter) IsNotEmpty() *Filter {
+	return p.condition(&notion.PeopleCondition{IsNotEmpty: true})
+}
+
+// FilesFilter builds conditions on files properties.
+type FilesFilter struct {
+	property string
+}
+
+// Files filters on a files property.
+func Files(property string) FilesFilter {
+	return FilesFilter{property}
+}
+
+// IsEmpty matches properties without files.
+func (f FilesFilter) IsEmpty() *Filter {
+	return single(f.property, func(s *notion.SingleFilter) { s.Files = &notion.FileCondition{IsEmpty: true} })
+}
+
+// IsNotEmpty matches properties with at least one file.
+func (f FilesFilter) IsNotEmpty() *Filter {
+	return single(f.property, func(s *notion.SingleFilter) { s.Files = &notion.FileCondition{IsNotEmpty: true} })
+}
+
+// RelationFilter builds conditions on relation properties.
+type RelationFilter struct {
+	property string
+}
+
+// Relation filters on a relation property.
+func Relation(property string) RelationFilter {
+	return RelationFilter{property}
+}
+
+func (r RelationFilter) condition(c *notion.RelationCondition) *Filter {
+	return single(r.property, func(f *notion.SingleFilter) { f.Relation = c })
+}
+
+// Contains matches relations that include the page with pageID.
+func (r RelationFilter) Contains(pageID string) *Filter {
+	return r.condition(&notion.RelationCondition{Contains: pageID})
+}
+
+// DoesNotContain matches relations that don't include the page with pageID.
+func (r RelationFilter) DoesNotContain(pageID string) *Filter {
+	return r.condition(&notion.RelationCondition{DoesNotContain: pageID})
+}
+
+// IsEmpty matches relations without pages.
+func (r RelationFilter) IsEmpty() *Filter {
+	return r.condition(&notion.RelationCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches relations with at least one page.
+func (r RelationFilter) IsNotEmpty() *Filter {
+	return r.condition(&notion.RelationCondition{IsNotEmpty: true})
+}
+
+// FormulaFilter builds conditions on the result of formula properties.
+type FormulaFilter struct {
+	property string
+}
+
+// Formula filters on a formula property.
+func Formula(property string) FormulaFilter {
+	return FormulaFilter{property}
+}
+
+// Text filters on the result of a formula that returns text.
+func (f FormulaFilter) Text() TextFilter {
+	return TextFilter{f.property, func(s *notion.SingleFilter, c *notion.TextCondition) {
+		s.Formula = &notion.FormulaCondition{Text: c}
+	}}
+}
+
+// Number filters on the result of a formula that returns a number.
+func (f FormulaFilter) Number() NumberFilter {
+	return NumberFilter{f.property, func(s *notion.SingleFilter, c *notion.NumberCondition) {
+		s.Formula = &notion.FormulaCondition{Number: c}
+	}}
+}
+
+// Checkbox filters on the result of a formula that returns a boolean.
+func (f FormulaFilter) Checkbox() CheckboxFilter {
+	return CheckboxFilter{f.property, func(s *notion.SingleFilter, c *notion.CheckboxCondition) {
+		s.Formula = &notion.FormulaCondition{Checkbox: c}
+	}}
+}
+
+// Date filters on the result of a formula that returns a date.
+func (f FormulaFilter) Date() DateFilter {
+	return DateFilter{f.property, func(s *notion.SingleFilter, c *notion.DateCondition) {
+		s.Formula = &notion.FormulaCondition{Date: c}
+	}}
+}
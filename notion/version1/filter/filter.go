@@ -0,0 +1,84 @@
+// Package filter builds database query filters for the Notion API.
+//
+// Filters marshal to the JSON of a SingleFilter or a CompoundFilter, so they can be used directly as
+// QueryDatabaseBodyParams.Filter:
+//
+//	params := &notion.QueryDatabaseBodyParams{
+//		Filter: filter.Text("Name").Contains("x").And(filter.Checkbox("Done").Equals(false)),
+//	}
+//
+// https://developers.notion.com/reference/post-database-query#post-database-query-filter
+package filter
+
+import (
+	"encoding/json"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+)
+
+// Filter is a condition on a single property, or an "and" / "or" of other filters.
+type Filter struct {
+	single   *notion.SingleFilter
+	operator string
+	filters  []*Filter
+}
+
+// And returns a filter that matches pages matched by all of filters.
+func And(filters ...*Filter) *Filter {
+	return &Filter{operator: "and", filters: filters}
+}
+
+// Or returns a filter that matches pages matched by any of filters.
+func Or(filters ...*Filter) *Filter {
+	return &Filter{operator: "or", filters: filters}
+}
+
+// And returns a filter that matches pages matched by f and all of others.
+// If f is already an "and" filter, others are added to it instead of nesting it.
+func (f *Filter) And(others ...*Filter) *Filter {
+	return f.combine("and", others)
+}
+
+// Or returns a filter that matches pages matched by f or any of others.
+// If f is already an "or" filter, others are added to it instead of nesting it.
+func (f *Filter) Or(others ...*Filter) *Filter {
+	return f.combine("or", others)
+}
+
+func (f *Filter) combine(operator string, others []*Filter) *Filter {
+	if f.operator == operator {
+		filters := append(append([]*Filter{}, f.filters...), others...)
+		return &Filter{operator: operator, filters: filters}
+	}
+	return &Filter{operator: operator, filters: append([]*Filter{f}, others...)}
+}
+
+// Build returns f as a *notion.SingleFilter or a *notion.CompoundFilter.
+func (f *Filter) Build() interface{} {
+	if f.single != nil {
+		single := *f.single
+		return &single
+	}
+
+	filters := make([]interface{}, len(f.filters))
+	for i, filter := range f.filters {
+		filters[i] = filter.Build()
+	}
+
+	if f.operator == "or" {
+		return &notion.CompoundFilter{OR: filters}
+	}
+	return &notion.CompoundFilter{AND: filters}
+}
+
+func (f *Filter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Build())
+}
+
+func single(property string, set func(*notion.SingleFilter)) *Filter {
+	f := &notion.SingleFilter{Property: property}
+	set(f)
+	return &Filter{single: f}
+}
+
+// empty is the value of the relative date conditions, e.g. past_week.
+var empty = struct{}{}
@@ -0,0 +1,94 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/filter"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertJSON(t *testing.T, expected string, v interface{}) {
+	data, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(data))
+}
+
+func TestFilter_Single(t *testing.T) {
+	cases := []struct {
+		expected string
+		filter   *filter.Filter
+	}{
+		{`{"property":"Name","rich_text":{"contains":"x"}}`, filter.Text("Name").Contains("x")},
+		{`{"property":"Name","title":{"is_empty":true}}`, filter.Title("Name").Equals("")},
+		{`{"property":"Site","url":{"is_not_empty":true}}`, filter.URL("Site").IsNotEmpty()},
+		{`{"property":"Done","checkbox":{"equals":false}}`, filter.Checkbox("Done").Equals(false)},
+		{`{"property":"Price","number":{"greater_than":0}}`, filter.Number("Price").GreaterThan(0)},
+		{`{"property":"Price","number":{"equals":0}}`, filter.Number("Price").Equals(0)},
+		{`{"property":"Status","select":{"is_empty":true}}`, filter.Select("Status").Equals("")},
+		{`{"property":"Tags","multi_select":{"contains":"infra"}}`, filter.MultiSelect("Tags").Contains("infra")},
+		{`{"property":"Due","date":{"past_week":{}}}`, filter.Date("Due").PastWeek()},
+		{`{"property":"Due","date":{"on_or_after":"2021-05-10"}}`, filter.Date("Due").OnOrAfter("2021-05-10")},
+		{`{"property":"Owner","people":{"contains":"123"}}`, filter.People("Owner").Contains("123")},
+		{`{"property":"Files","files":{"is_empty":true}}`, filter.Files("Files").IsEmpty()},
+		{`{"property":"Parent","relation":{"is_not_empty":true}}`, filter.Relation("Parent").IsNotEmpty()},
+		{`{"property":"Total","formula":{"number":{"less_than":10}}}`, filter.Formula("Total").Number().LessThan(10)},
+		{`{"property":"Late","formula":{"checkbox":{"equals":true}}}`, filter.Formula("Late").Checkbox().Equals(true)},
+	}
+
+	for _, c := range cases {
+		assertJSON(t, c.expected, c.filter)
+	}
+}
+
+func TestFilter_Compound(t *testing.T) {
+	f := filter.Text("Name").Contains("x").And(filter.Checkbox("Done").Equals(false))
+	assertJSON(t, `{"and":[{"property":"Name","rich_text":{"contains":"x"}},{"property":"Done","checkbox":{"equals":false}}]}`, f)
+
+	// Chaining the same operator doesn't nest.
+	f = f.And(filter.Number("Price").IsEmpty())
+	assertJSON(t, `{"and":[{"property":"Name","rich_text":{"contains":"x"}},{"property":"Done","checkbox":{"equals":false}},{"property":"Price","number":{"is_empty":true}}]}`, f)
+
+	nested := filter.Or(
+		filter.Select("Status").Equals("Done"),
+		filter.And(filter.Select("Status").Equals("Doing"), filter.Date("Due").NextWeek()),
+	)
+	assertJSON(t, `{"or":[{"property":"Status","select":{"equals":"Done"}},{"and":[{"property":"Status","select":{"equals":"Doing"}},{"property":"Due","date":{"next_week":{}}}]}]}`, nested)
+}
+
+func TestFilter_Build(t *testing.T) {
+	single := filter.MultiSelect("Tags").Contains("TagTest").Build()
+	assert.Equal(t, &notion.SingleFilter{
+		Property:    "Tags",
+		MultiSelect: &notion.MultiSelectCondition{Contains: "TagTest"},
+	}, single)
+
+	compound := filter.Or(filter.Title("Name").Contains("a"), filter.Title("Name").Contains("b")).Build()
+	assert.IsType(t, &notion.CompoundFilter{}, compound)
+}
+
+func TestFilter_QueryDatabaseBodyParams(t *testing.T) {
+	params := &notion.QueryDatabaseBodyParams{
+		Filter: filter.MultiSelect("Tags").Contains("TagTest"),
+	}
+	assertJSON(t, `{"filter":{"property":"Tags","multi_select":{"contains":"TagTest"}}}`, params)
+}
+
+func TestFilter_EmptyTextValue(t *testing.T) {
+	db := &notion.Database{Properties: map[string]notion.PropertyObj{"Name": {Type: "rich_text"}}}
+
+	for _, f := range []*filter.Filter{
+		filter.Text("Name").Contains(""),
+		filter.Text("Name").DoesNotContain(""),
+		filter.Text("Name").StartsWith(""),
+		filter.Text("Name").EndsWith(""),
+	} {
+		// The condition has no operator left, which Notion rejects.
+		assertJSON(t, `{"property":"Name","rich_text":{}}`, f)
+
+		err := notion.ValidateQuery(db, &notion.QueryDatabaseBodyParams{Filter: f})
+		var validationErr *notion.QueryValidationError
+		assert.True(t, errors.As(err, &validationErr))
+	}
+}
@@ -0,0 +1,354 @@
+// Package gen generates Go code for the pages of a Notion database from its schema.
+//
+// For a database, it writes a row struct with notion tags for UnmarshalPage and MarshalProperties, constants for
+// the property names and the options of select and multi-select properties, filter builders for each property
+// and functions to query, create and update rows. The notiongen command runs it for use with go:generate.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Config configures Generate.
+type Config struct {
+	// Package is the name of the package of the generated file. Required.
+	Package string
+
+	// TypeName is the name of the row struct, and the prefix of every other generated name.
+	// Defaults to the title of the database.
+	TypeName string
+}
+
+// property is a database property and the Go code generated for it.
+type property struct {
+	name string
+	obj  notion.PropertyObj
+
+	// constant is the name of the constant holding the property name, field the name of the struct field,
+	// goType its type, and optionType the type of the options of select and multi-select properties.
+	constant   string
+	field      string
+	goType     string
+	optionType string
+	options    []option
+
+	// filter is the filter package function that builds filters on the property, and filterType its result.
+	filter     string
+	filterType string
+}
+
+type option struct {
+	name  string
+	ident string
+}
+
+// fieldTypes maps each property type to the type of its struct field, its filter function and filter type.
+var fieldTypes = map[string][3]string{
+	"title":            {"string", "Title", "TextFilter"},
+	"rich_text":        {"string", "Text", "TextFilter"},
+	"url":              {"string", "URL", "TextFilter"},
+	"email":            {"string", "Email", "TextFilter"},
+	"phone_number":     {"string", "Phone", "TextFilter"},
+	"number":           {"float64", "Number", "NumberFilter"},
+	"checkbox":         {"bool", "Checkbox", "CheckboxFilter"},
+	"select":           {"string", "Select", "SelectFilter"},
+	"multi_select":     {"[]string", "MultiSelect", "MultiSelectFilter"},
+	"date":             {"time.Time", "Date", "DateFilter"},
+	"created_time":     {"time.Time", "CreatedTime", "DateFilter"},
+	"last_edited_time": {"time.Time", "LastEditedTime", "DateFilter"},
+	"people":           {"[]string", "People", "PeopleFilter"},
+	"created_by":       {"string", "CreatedBy", "PeopleFilter"},
+	"last_edited_by":   {"string", "LastEditedBy", "PeopleFilter"},
+	"file":             {"[]string", "Files", "FilesFilter"},
+	"files":            {"[]string", "Files", "FilesFilter"},
+	"relation":         {"[]string", "Relation", "RelationFilter"},
+	"formula":          {"notion.PageProperty", "Formula", "FormulaFilter"},
+	"rollup":           {"notion.PageProperty", "", ""},
+}
+
+// Generate returns gofmt-ed Go source for the pages of db, as returned by RetrieveDatabase.
+func Generate(db *notion.Database, config Config) ([]byte, error) {
+	if config.Package == "" {
+		return nil, errors.New("gen: Config.Package is required")
+	}
+
+	title := databaseTitle(db)
+	typeName := config.TypeName
+	switch {
+	case typeName != "":
+	case title != "":
+		typeName = identifier(title)
+	default:
+		typeName = "Row"
+	}
+
+	g := &generator{db: db, title: title, typeName: typeName, names: map[string]bool{}}
+	g.reserve(typeName, typeName+"DatabaseID", typeName+"Where", "Query"+typeName, "Create"+typeName,
+		"Update"+typeName)
+	g.properties()
+	g.generate(config.Package)
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: generated invalid code: %v", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	db           *notion.Database
+	title        string
+	typeName     string
+	names        map[string]bool
+	fields       map[string]bool
+	props        []*property
+	buf          bytes.Buffer
+	importTime   bool
+	importFilter bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) reserve(names ...string) {
+	for _, name := range names {
+		g.names[name] = true
+	}
+}
+
+// unique returns name, or name followed by a number if it is already used in the scope of used.
+func unique(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%v%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// properties sorts the properties of the database by name and picks the Go names of their code.
+func (g *generator) properties() {
+	names := make([]string, 0, len(g.db.Properties))
+	for name := range g.db.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// ID holds the ID of the page.
+	g.fields = map[string]bool{"ID": true}
+	for _, name := range names {
+		p := &property{name: name, obj: g.db.Properties[name]}
+		types, ok := fieldTypes[p.obj.Type]
+		// Struct tags can't hold property names with commas or backquotes.
+		if !ok || strings.ContainsAny(name, ",`") {
+			continue
+		}
+		p.goType, p.filter, p.filterType = types[0], types[1], types[2]
+		p.field = unique(g.fields, identifier(name))
+		p.constant = unique(g.names, g.typeName+"Property"+p.field)
+
+		switch {
+		case p.obj.Type == "select" && p.obj.Select != nil:
+			p.optionType = unique(g.names, g.typeName+p.field)
+			p.goType = p.optionType
+			for _, o := range p.obj.Select.Options {
+				p.options = append(p.options, option{name: o.Name})
+			}
+		case p.obj.Type == "multi_select" && p.obj.MultiSelect != nil:
+			p.optionType = unique(g.names, g.typeName+p.field)
+			p.goType = "[]" + p.optionType
+			for _, o := range p.obj.MultiSelect.Options {
+				p.options = append(p.options, option{name: o.Name})
+			}
+		}
+		for i := range p.options {
+			p.options[i].ident = unique(g.names, p.optionType+identifier(p.options[i].name))
+		}
+		if p.goType == "time.Time" {
+			g.importTime = true
+		}
+		if p.filter != "" {
+			g.importFilter = true
+		}
+		g.props = append(g.props, p)
+	}
+}
+
+func (g *generator) generate(pkg string) {
+	t := g.typeName
+
+	g.printf("// Code generated by notiongen from the %q database. DO NOT EDIT.\n\n", g.title)
+	g.printf("package %v\n\n", pkg)
+	g.printf("import (\n\t\"context\"\n")
+	g.printf("\tnotion \"github.com/oyekanmiayo/go-notion/notion/version1\"\n")
+	if g.importFilter {
+		g.printf("\t\"github.com/oyekanmiayo/go-notion/notion/version1/filter\"\n")
+	}
+	if g.importTime {
+		g.printf("\t\"time\"\n")
+	}
+	g.printf(")\n\n")
+
+	g.printf("// %vDatabaseID is the ID of the %q database.\n", t, g.title)
+	g.printf("const %vDatabaseID = %q\n\n", t, g.db.ID)
+
+	if len(g.props) > 0 {
+		g.printf("// Names of the properties of the %q database.\n", g.title)
+		g.printf("const (\n")
+		for _, p := range g.props {
+			g.printf("\t%v = %q\n", p.constant, p.name)
+		}
+		g.printf(")\n\n")
+	}
+
+	for _, p := range g.props {
+		if p.optionType == "" {
+			continue
+		}
+		g.printf("// %v is an option of the %q property.\n", p.optionType, p.name)
+		g.printf("type %v string\n\n", p.optionType)
+		if len(p.options) > 0 {
+			g.printf("// Options of the %q property.\n", p.name)
+			g.printf("const (\n")
+			for _, o := range p.options {
+				g.printf("\t%v %v = %q\n", o.ident, p.optionType, o.name)
+			}
+			g.printf(")\n\n")
+		}
+	}
+
+	g.printf("// %v is a page of the %q database.\n", t, g.title)
+	g.printf("type %v struct {\n", t)
+	g.printf("\tID string\n")
+	for _, p := range g.props {
+		g.printf("\t%v %v `notion:\"%v,%v\"`\n", p.field, p.goType, strings.ReplaceAll(p.name, `"`, `\"`),
+			tagType(p.obj.Type))
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %vWhere builds filters on the properties of %v.\n", t, t)
+	g.printf("var %vWhere = struct {\n", t)
+	for _, p := range g.props {
+		if p.filter != "" {
+			g.printf("\t%v filter.%v\n", p.field, p.filterType)
+		}
+	}
+	g.printf("}{\n")
+	for _, p := range g.props {
+		if p.filter != "" {
+			g.printf("\t%v: filter.%v(%v),\n", p.field, p.filter, p.constant)
+		}
+	}
+	g.printf("}\n\n")
+
+	for _, p := range g.props {
+		switch {
+		case p.optionType != "" && p.obj.Type == "select":
+			g.printf("// %vIs matches pages whose %q is option.\n", p.optionType, p.name)
+			g.printf("func %vIs(option %v) *filter.Filter {\n", p.optionType, p.optionType)
+			g.printf("\treturn %vWhere.%v.Equals(string(option))\n}\n\n", t, p.field)
+		case p.optionType != "":
+			g.printf("// %vContains matches pages whose %q include option.\n", p.optionType, p.name)
+			g.printf("func %vContains(option %v) *filter.Filter {\n", p.optionType, p.optionType)
+			g.printf("\treturn %vWhere.%v.Contains(string(option))\n}\n\n", t, p.field)
+		}
+	}
+
+	g.printf(`// Query%[1]v returns the pages of the %[2]q database matching params, following every page of results.
+func Query%[1]v(ctx context.Context, client *notion.Client, params *notion.QueryDatabaseBodyParams) ([]%[1]v, error) {
+	var rows []%[1]v
+	it := client.Databases.QueryIter(ctx, %[1]vDatabaseID, params)
+	for it.Next() {
+		page := it.Value()
+		row := %[1]v{ID: page.ID}
+		if err := notion.UnmarshalPage(&page, &row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, it.Err()
+}
+
+// Create%[1]v adds row to the %[2]q database and sets row.ID to the ID of the new page.
+func Create%[1]v(ctx context.Context, client *notion.Client, row *%[1]v) error {
+	params, err := notion.MarshalCreatePageParams(notion.NewDatabaseParent(%[1]vDatabaseID), row)
+	if err != nil {
+		return err
+	}
+	page, _, err := client.Pages.CreatePage(ctx, params)
+	if err != nil {
+		return err
+	}
+	row.ID = page.ID
+	return nil
+}
+
+// Update%[1]v sets the properties of the page with row.ID to the fields of row.
+func Update%[1]v(ctx context.Context, client *notion.Client, row *%[1]v) error {
+	params, err := notion.MarshalUpdatePageParams(row)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.Pages.UpdatePageProperties(ctx, row.ID, params)
+	return err
+}
+`, t, g.title)
+}
+
+// tagType returns the property type to put in struct tags for propertyType.
+func tagType(propertyType string) string {
+	if propertyType == "file" {
+		return "files"
+	}
+	return propertyType
+}
+
+func databaseTitle(db *notion.Database) string {
+	var b strings.Builder
+	for _, rt := range db.Title {
+		if rt.PlainText == "" && rt.Text != nil {
+			b.WriteString(rt.Text.Content)
+			continue
+		}
+		b.WriteString(rt.PlainText)
+	}
+	return b.String()
+}
+
+// initialisms are the words identifier writes in upper case.
+var initialisms = map[string]bool{"ID": true, "URL": true, "API": true, "HTTP": true, "JSON": true}
+
+// identifier turns s, e.g. "Due date", into an exported Go identifier, e.g. "DueDate".
+func identifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	name := b.String()
+	switch {
+	case name == "":
+		return "Property"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "X" + name
+	}
+	return name
+}
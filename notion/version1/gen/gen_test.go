@@ -0,0 +1,89 @@
+package gen_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/gen"
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+var testDB = &notion.Database{
+	Object: "database",
+	ID:     "668d797c-76fa-4934-9b05-ad288df2d136",
+	Title:  []notion.RichText{{PlainText: "Tasks"}},
+	Properties: map[string]notion.PropertyObj{
+		"Name":     {ID: "title", Type: "title"},
+		"Due date": {ID: "a1", Type: "date"},
+		"Status": {ID: "a2", Type: "select", Select: &notion.SelectConfig{
+			Options: []notion.SelectOption{{Name: "To do"}, {Name: "Done"}},
+		}},
+		"Tags": {ID: "a3", Type: "multi_select", MultiSelect: &notion.MultiSelectConfig{
+			Options: []notion.MultiSelectOption{{Name: "infra"}},
+		}},
+		"ID":       {ID: "a4", Type: "number"},
+		"Score":    {ID: "a5", Type: "formula"},
+		"Total":    {ID: "a6", Type: "rollup"},
+		"Homepage": {ID: "a7", Type: "url"},
+		"a, b":     {ID: "a8", Type: "checkbox"},
+	},
+}
+
+// typeCheck reports the errors that compiling src, a generated file, would.
+func typeCheck(t *testing.T, src []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", src, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = config.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	assert.Nil(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := gen.Generate(testDB, gen.Config{Package: "tasks", TypeName: "Task"})
+	assert.Nil(t, err)
+	typeCheck(t, src)
+
+	code := string(src)
+	assert.Contains(t, code, "// Code generated by notiongen from the \"Tasks\" database. DO NOT EDIT.\n\npackage tasks\n")
+	assert.Contains(t, code, `const TaskDatabaseID = "668d797c-76fa-4934-9b05-ad288df2d136"`)
+	assert.Contains(t, code, `TaskPropertyDueDate  = "Due date"`)
+	assert.Contains(t, code, "type TaskStatus string\n")
+	assert.Contains(t, code, `TaskStatusToDo TaskStatus = "To do"`)
+	assert.Contains(t, code, `TaskTagsInfra TaskTags = "infra"`)
+	assert.Contains(t, code, "type Task struct {\n"+
+		"\tID       string\n"+
+		"\tDueDate  time.Time           `notion:\"Due date,date\"`\n"+
+		"\tHomepage string              `notion:\"Homepage,url\"`\n"+
+		"\tID2      float64             `notion:\"ID,number\"`\n"+
+		"\tName     string              `notion:\"Name,title\"`\n"+
+		"\tScore    notion.PageProperty `notion:\"Score,formula\"`\n"+
+		"\tStatus   TaskStatus          `notion:\"Status,select\"`\n"+
+		"\tTags     []TaskTags          `notion:\"Tags,multi_select\"`\n"+
+		"\tTotal    notion.PageProperty `notion:\"Total,rollup\"`\n"+
+		"}\n")
+	assert.Contains(t, code, "\tStatus:   filter.Select(TaskPropertyStatus),\n")
+	assert.NotContains(t, code, "Total:")
+	assert.Contains(t, code, "func TaskStatusIs(option TaskStatus) *filter.Filter {")
+	assert.Contains(t, code, "func QueryTask(ctx context.Context, client *notion.Client, "+
+		"params *notion.QueryDatabaseBodyParams) ([]Task, error) {")
+}
+
+func TestGenerate_Defaults(t *testing.T) {
+	src, err := gen.Generate(&notion.Database{ID: "1", Title: []notion.RichText{{PlainText: "2021 reading list"}}},
+		gen.Config{Package: "reading"})
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), "type X2021ReadingList struct {")
+	assert.NotContains(t, string(src), `"time"`)
+	assert.NotContains(t, string(src), `/filter"`)
+	typeCheck(t, src)
+
+	_, err = gen.Generate(testDB, gen.Config{})
+	assert.NotNil(t, err)
+}
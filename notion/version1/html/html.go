@@ -0,0 +1,238 @@
+// Package html renders Notion blocks and rich text as semantic HTML.
+//
+// Blocks render through html/template templates named after their type, which callers can override, e.g. to add
+// classes or render blocks that have no default HTML:
+//
+//	r := &html.Renderer{Templates: template.Must(template.New("").Parse(
+//		`{{define "heading_1"}}<h2 class="title">{{.Text}}</h2>{{end}}`,
+//	))}
+//	out, err := r.Render(blocks)
+//
+// Text is always escaped, and URLs with schemes other than http, https and mailto are replaced.
+package html
+
+import (
+	"bytes"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"html/template"
+)
+
+// Renderer renders blocks as HTML. The zero value is ready to use.
+type Renderer struct {
+	// Templates overrides the default templates. A template named after a block type, e.g. "paragraph", renders
+	// blocks of that type, and templates named "bulleted_list", "numbered_list" and "to_do_list" render the lists
+	// around consecutive list items. Templates execute with a *BlockData. Blocks of types without a template,
+	// e.g. table_of_contents, breadcrumb and unknown types, are left out.
+	Templates *template.Template
+
+	// Mention renders mentions of users, pages, databases and dates.
+	// By default, they render as their plain text, linked to the mentioned page or database.
+	Mention func(mention notion.RichText) template.HTML
+}
+
+// BlockData is what block templates execute with.
+type BlockData struct {
+	// Block is the block being rendered.
+	Block notion.Block
+
+	// Text is the rendered text of the block, e.g. of a paragraph, a heading or a table of contents, and PlainText
+	// its plain text, e.g. the code of a code block.
+	Text      template.HTML
+	PlainText string
+
+	// Children is the rendered children of the block, or for lists, their items.
+	Children template.HTML
+
+	// Caption is the rendered caption of code, media, bookmark and embed blocks, and PlainCaption its plain text.
+	Caption      template.HTML
+	PlainCaption string
+
+	// URL is the URL of media, bookmark and embed blocks, and the notion.so URL of child pages, child databases
+	// and links to pages. Title is the title of child pages and databases.
+	URL   string
+	Title string
+
+	// Checked is whether a to-do is checked.
+	Checked bool
+
+	// Language is the language of a code block.
+	Language string
+
+	// Icon is the emoji of a callout, and IconURL the URL of its icon image.
+	Icon    string
+	IconURL string
+
+	// Expression is the expression of an equation.
+	Expression string
+
+	// Rows is the rendered cells of a table, and HasColumnHeader and HasRowHeader whether its first row and
+	// column are headers.
+	Rows            [][]template.HTML
+	HasColumnHeader bool
+	HasRowHeader    bool
+}
+
+// Render renders blocks as HTML with the default Renderer.
+func Render(blocks []notion.Block) (template.HTML, error) {
+	return (&Renderer{}).Render(blocks)
+}
+
+// RenderRichText renders rich text as inline HTML with the default Renderer.
+func RenderRichText(richText []notion.RichText) template.HTML {
+	return (&Renderer{}).RenderRichText(richText)
+}
+
+// Render renders blocks as HTML. It only fails if a template does.
+func (r *Renderer) Render(blocks []notion.Block) (template.HTML, error) {
+	var b bytes.Buffer
+	if err := r.blocks(&b, blocks); err != nil {
+		return "", err
+	}
+	return template.HTML(b.String()), nil
+}
+
+// blocks renders blocks, wrapping consecutive list items of the same kind in a list.
+func (r *Renderer) blocks(b *bytes.Buffer, blocks []notion.Block) error {
+	for i := 0; i < len(blocks); {
+		list := listTemplate(blocks[i].Type)
+		if list == "" {
+			if err := r.block(b, &blocks[i]); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+
+		var items bytes.Buffer
+		for ; i < len(blocks) && listTemplate(blocks[i].Type) == list; i++ {
+			if err := r.block(&items, &blocks[i]); err != nil {
+				return err
+			}
+		}
+		if err := r.execute(b, list, &BlockData{Children: template.HTML(items.String())}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listTemplate returns the name of the template of the list around blocks of blockType, or "" if they aren't list
+// items.
+func listTemplate(blockType string) string {
+	switch blockType {
+	case "bulleted_list_item":
+		return "bulleted_list"
+	case "numbered_list_item":
+		return "numbered_list"
+	case "to_do":
+		return "to_do_list"
+	}
+	return ""
+}
+
+func (r *Renderer) block(b *bytes.Buffer, block *notion.Block) error {
+	data := &BlockData{Block: *block}
+	var text, caption []notion.RichText
+
+	switch {
+	case block.Paragraph != nil:
+		text = block.Paragraph.Text
+	case block.HeadingOne != nil:
+		text = block.HeadingOne.Text
+	case block.HeadingTwo != nil:
+		text = block.HeadingTwo.Text
+	case block.HeadingThree != nil:
+		text = block.HeadingThree.Text
+	case block.BulletedListItem != nil:
+		text = block.BulletedListItem.Text
+	case block.NumberedListItem != nil:
+		text = block.NumberedListItem.Text
+	case block.ToDo != nil:
+		text, data.Checked = block.ToDo.Text, block.ToDo.Checked
+	case block.Toggle != nil:
+		text = block.Toggle.Text
+	case block.Quote != nil:
+		text = block.Quote.Text
+	case block.Template != nil:
+		text = block.Template.Text
+	case block.Callout != nil:
+		text = block.Callout.Text
+		if icon := block.Callout.Icon; icon != nil {
+			data.Icon = icon.Emoji
+			switch {
+			case icon.External != nil:
+				data.IconURL = icon.External.URL
+			case icon.File != nil:
+				data.IconURL = icon.File.URL
+			}
+		}
+	case block.Code != nil:
+		text, caption, data.Language = block.Code.Text, block.Code.Caption, block.Code.Language
+	case block.Image != nil || block.Video != nil || block.File != nil || block.PDF != nil:
+		file := block.Image
+		for _, f := range []*notion.FileBlock{block.Video, block.File, block.PDF} {
+			if file == nil {
+				file = f
+			}
+		}
+		data.URL, caption = file.URL(), file.Caption
+	case block.Bookmark != nil:
+		data.URL, caption = block.Bookmark.URL, block.Bookmark.Caption
+	case block.Embed != nil:
+		data.URL, caption = block.Embed.URL, block.Embed.Caption
+	case block.Equation != nil:
+		data.Expression = block.Equation.Expression
+	case block.ChildPage != nil:
+		data.URL, data.Title = notion.PageURL(block.ID), block.ChildPage.Title
+	case block.ChildDatabase != nil:
+		data.URL, data.Title = notion.PageURL(block.ID), block.ChildDatabase.Title
+	case block.LinkToPage != nil:
+		data.URL = notion.PageURL(block.LinkToPage.PageID + block.LinkToPage.DatabaseID)
+	case block.Table != nil:
+		data.HasColumnHeader, data.HasRowHeader = block.Table.HasColumnHeader, block.Table.HasRowHeader
+		for _, row := range block.Table.Children {
+			if row.TableRow == nil {
+				continue
+			}
+			cells := make([]template.HTML, len(row.TableRow.Cells))
+			for i, cell := range row.TableRow.Cells {
+				cells[i] = r.RenderRichText(cell)
+			}
+			data.Rows = append(data.Rows, cells)
+		}
+	}
+
+	data.Text, data.PlainText = r.RenderRichText(text), notion.PlainText(text)
+	data.Caption, data.PlainCaption = r.RenderRichText(caption), notion.PlainText(caption)
+	if block.Table == nil {
+		var children bytes.Buffer
+		if err := r.blocks(&children, block.Children()); err != nil {
+			return err
+		}
+		data.Children = template.HTML(children.String())
+	}
+	return r.execute(b, block.Type, data)
+}
+
+// execute executes the template called name, preferring r.Templates to the defaults. There is nothing to execute
+// for names without a template.
+func (r *Renderer) execute(b *bytes.Buffer, name string, data *BlockData) error {
+	if name == "" {
+		return nil
+	}
+	var t *template.Template
+	if r.Templates != nil {
+		t = r.Templates.Lookup(name)
+	}
+	if t == nil {
+		t = defaultTemplates.Lookup(name)
+	}
+	if t == nil {
+		return nil
+	}
+	if err := t.Execute(b, data); err != nil {
+		return fmt.Errorf("html: rendering %v: %v", name, err)
+	}
+	return nil
+}
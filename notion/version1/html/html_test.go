@@ -0,0 +1,110 @@
+package html_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/html"
+	"github.com/stretchr/testify/assert"
+	"html/template"
+	"testing"
+)
+
+func text(content string) []notion.RichText {
+	return []notion.RichText{{Type: "text", Text: &notion.Text{Content: content}}}
+}
+
+func styled(content string, annotations notion.Annotations) notion.RichText {
+	return notion.RichText{Type: "text", Text: &notion.Text{Content: content}, Annotations: &annotations}
+}
+
+func TestRender(t *testing.T) {
+	blocks := []notion.Block{
+		{Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{Text: text("Title <1>")}},
+		{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: []notion.RichText{
+			styled("bold", notion.Annotations{Bold: true, Color: "red_background"}),
+			{Type: "text", Text: &notion.Text{Content: " & "}},
+			{Type: "text", Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com/?a=1&b=2"}}},
+			{Type: "text", Text: &notion.Text{Content: " bad", Link: &notion.Link{URL: "javascript:alert(1)"}}},
+		}}},
+		{Type: "bulleted_list_item", BulletedListItem: &notion.BulletedListItemBlock{
+			Text: text("One"),
+			Children: []notion.Block{
+				{Type: "numbered_list_item", NumberedListItem: &notion.NumberedListItemBlock{Text: text("Nested")}},
+				{Type: "numbered_list_item", NumberedListItem: &notion.NumberedListItemBlock{Text: text("Nested too")}},
+			},
+		}},
+		{Type: "bulleted_list_item", BulletedListItem: &notion.BulletedListItemBlock{Text: text("Two")}},
+		{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Done"), Checked: true}},
+		{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Not done")}},
+		{Type: "toggle", Toggle: &notion.ToggleBlock{Text: text("More"), Children: []notion.Block{
+			{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("Line one\nLine two")}},
+		}}},
+		{Type: "code", Code: &notion.CodeBlock{Text: text("a < b"), Language: "go"}},
+		{Type: "image", Image: &notion.FileBlock{Type: "external", External: &notion.ExternalFile{URL: "https://example.com/a.png"},
+			Caption: text("A \"cat\"")}},
+		{Type: "table", Table: &notion.TableBlock{TableWidth: 2, HasColumnHeader: true, Children: []notion.Block{
+			{Type: "table_row", TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{text("a"), text("b")}}},
+			{Type: "table_row", TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{text("1"), text("2")}}},
+		}}},
+		{ID: "d4e5", Type: "child_page", ChildPage: &notion.ChildPageBlock{Title: "Sub page"}},
+		{Type: "table_of_contents", TableOfContents: &notion.TableOfContentsBlock{}},
+	}
+
+	out, err := html.Render(blocks)
+	assert.Nil(t, err)
+	assert.Equal(t, template.HTML(`<h1>Title &lt;1&gt;</h1>`+
+		`<p><span class="notion-red-background"><strong>bold</strong></span> &amp; `+
+		`<a href="https://example.com/?a=1&amp;b=2">link</a> bad</p>`+
+		`<ul><li>One<ol><li>Nested</li><li>Nested too</li></ol></li><li>Two</li></ul>`+
+		`<ul class="notion-to-do-list"><li><label><input type="checkbox" disabled checked> Done</label></li>`+
+		`<li><label><input type="checkbox" disabled> Not done</label></li></ul>`+
+		`<details><summary>More</summary><p>Line one<br>Line two</p></details>`+
+		`<figure class="notion-code"><pre><code class="language-go">a &lt; b</code></pre></figure>`+
+		`<figure><img src="https://example.com/a.png" alt="A &#34;cat&#34;"><figcaption>A &#34;cat&#34;</figcaption></figure>`+
+		`<table><tr><th>a</th><th>b</th></tr><tr><td>1</td><td>2</td></tr></table>`+
+		`<p class="notion-page"><a href="https://www.notion.so/d4e5">Sub page</a></p>`), out)
+}
+
+func TestRenderer_Templates(t *testing.T) {
+	r := &html.Renderer{
+		Templates: template.Must(template.New("").Parse(
+			`{{define "heading_1"}}<h2 class="title">{{.Text}}</h2>{{end}}` +
+				`{{define "table_of_contents"}}<nav></nav>{{end}}` +
+				`{{define "bulleted_list"}}<ul class="list">{{.Children}}</ul>{{end}}`,
+		)),
+		Mention: func(mention notion.RichText) template.HTML {
+			return template.HTML("<b>" + template.HTMLEscapeString(mention.PlainText) + "</b>")
+		},
+	}
+
+	out, err := r.Render([]notion.Block{
+		{Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{Text: []notion.RichText{
+			{Type: "mention", PlainText: "@Ada", Mention: &notion.Mention{Type: "user", UserMention: &notion.User{ID: "u1"}}},
+		}}},
+		{Type: "table_of_contents", TableOfContents: &notion.TableOfContentsBlock{}},
+		{Type: "bulleted_list_item", BulletedListItem: &notion.BulletedListItemBlock{Text: text("Item")}},
+		{Type: "divider", Divider: &notion.DividerBlock{}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, template.HTML(`<h2 class="title"><b>@Ada</b></h2><nav></nav><ul class="list"><li>Item</li></ul><hr>`), out)
+}
+
+func TestRenderer_TemplateError(t *testing.T) {
+	r := &html.Renderer{Templates: template.Must(template.New("").Parse(
+		`{{define "divider"}}{{.Missing}}{{end}}`,
+	))}
+
+	_, err := r.Render([]notion.Block{{Type: "divider", Divider: &notion.DividerBlock{}}})
+	assert.NotNil(t, err)
+}
+
+func TestRenderRichText(t *testing.T) {
+	out := html.RenderRichText([]notion.RichText{
+		styled("x", notion.Annotations{Code: true, Italic: true, Underline: true, Strikethrough: true}),
+		{Type: "equation", Equation: &notion.Equation{Expression: "a<b"}},
+		{Type: "mention", PlainText: "Plans", Mention: &notion.Mention{Type: "page",
+			PageMention: &notion.PageMention{ID: "a1b2-c3"}}},
+	})
+	assert.Equal(t, template.HTML(`<em><u><s><code>x</code></s></u></em>`+
+		`<span class="notion-equation">a&lt;b</span>`+
+		`<a href="https://www.notion.so/a1b2c3">Plans</a>`), out)
+}
@@ -0,0 +1,110 @@
+package html
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// RenderRichText renders rich text as inline HTML: annotations as strong, em, s, u and code elements, colors as
+// span elements with a notion-<color> class, e.g. notion-red or notion-red-background, and links as links.
+// Newlines render as br elements.
+func (r *Renderer) RenderRichText(richText []notion.RichText) template.HTML {
+	var b strings.Builder
+	for _, rt := range richText {
+		var out string
+		switch rt.Type {
+		case "equation":
+			if rt.Equation != nil {
+				out = `<span class="notion-equation">` + escape(rt.Equation.Expression) + `</span>`
+			}
+		case "mention":
+			if r.Mention != nil {
+				out = string(r.Mention(rt))
+			} else {
+				out = mention(rt)
+			}
+		default:
+			out = escape(rt.Content())
+			if rt.Text != nil && rt.Text.Link != nil {
+				out = link(out, rt.Text.Link.URL)
+			} else if rt.Href != "" {
+				out = link(out, rt.Href)
+			}
+		}
+		b.WriteString(annotate(out, rt.Annotations))
+	}
+	return template.HTML(b.String())
+}
+
+// annotate wraps html in the elements for annotations.
+func annotate(html string, a *notion.Annotations) string {
+	if html == "" || a == nil {
+		return html
+	}
+	if a.Code {
+		html = "<code>" + html + "</code>"
+	}
+	if a.Strikethrough {
+		html = "<s>" + html + "</s>"
+	}
+	if a.Underline {
+		html = "<u>" + html + "</u>"
+	}
+	if a.Italic {
+		html = "<em>" + html + "</em>"
+	}
+	if a.Bold {
+		html = "<strong>" + html + "</strong>"
+	}
+	if a.Color != "" && a.Color != "default" {
+		class := "notion-" + strings.ReplaceAll(a.Color, "_", "-")
+		html = `<span class="` + template.HTMLEscapeString(class) + `">` + html + "</span>"
+	}
+	return html
+}
+
+// mention renders a mention as its plain text, linked to the mentioned page or database.
+func mention(rt notion.RichText) string {
+	text := escape(rt.PlainText)
+	if rt.Href != "" {
+		return link(text, rt.Href)
+	}
+	if m := rt.Mention; m != nil {
+		switch {
+		case m.PageMention != nil:
+			return link(text, notion.PageURL(m.PageMention.ID))
+		case m.DatabaseMention != nil:
+			return link(text, notion.PageURL(m.DatabaseMention.ID))
+		}
+	}
+	return text
+}
+
+// link links html to href, or leaves it as it is if href isn't a safe URL.
+func link(html, href string) string {
+	if !safeURL(href) {
+		return html
+	}
+	return `<a href="` + template.HTMLEscapeString(href) + `">` + html + "</a>"
+}
+
+// safeURL reports whether s is a relative URL or has a scheme that can't run script, like html/template's URL
+// filter.
+func safeURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// escape escapes s for HTML text, turning newlines into line breaks.
+func escape(s string) string {
+	return strings.ReplaceAll(template.HTMLEscapeString(s), "\n", "<br>")
+}
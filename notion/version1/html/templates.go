@@ -0,0 +1,61 @@
+package html
+
+import "html/template"
+
+// defaultTemplates renders each block type that has an HTML form.
+var defaultTemplates = template.Must(template.New("").Parse(`
+{{- define "children"}}{{with .Children}}<div class="notion-children">{{.}}</div>{{end}}{{end -}}
+
+{{- define "paragraph"}}<p>{{.Text}}</p>{{template "children" .}}{{end -}}
+{{- define "heading_1"}}<h1>{{.Text}}</h1>{{end -}}
+{{- define "heading_2"}}<h2>{{.Text}}</h2>{{end -}}
+{{- define "heading_3"}}<h3>{{.Text}}</h3>{{end -}}
+
+{{- define "bulleted_list"}}<ul>{{.Children}}</ul>{{end -}}
+{{- define "numbered_list"}}<ol>{{.Children}}</ol>{{end -}}
+{{- define "to_do_list"}}<ul class="notion-to-do-list">{{.Children}}</ul>{{end -}}
+{{- define "bulleted_list_item"}}<li>{{.Text}}{{.Children}}</li>{{end -}}
+{{- define "numbered_list_item"}}<li>{{.Text}}{{.Children}}</li>{{end -}}
+{{- define "to_do" -}}
+<li><label><input type="checkbox" disabled{{if .Checked}} checked{{end}}> {{.Text}}</label>{{.Children}}</li>
+{{- end -}}
+
+{{- define "toggle"}}<details><summary>{{.Text}}</summary>{{.Children}}</details>{{end -}}
+{{- define "quote"}}<blockquote><p>{{.Text}}</p>{{.Children}}</blockquote>{{end -}}
+{{- define "callout" -}}
+<aside class="notion-callout">
+{{- if .IconURL}}<img class="notion-icon" src="{{.IconURL}}" alt="">{{else if .Icon}}<span class="notion-icon">{{.Icon}}</span>{{end -}}
+<div><p>{{.Text}}</p>{{.Children}}</div></aside>
+{{- end -}}
+{{- define "template"}}<div class="notion-template"><p>{{.Text}}</p>{{.Children}}</div>{{end -}}
+{{- define "code" -}}
+<figure class="notion-code"><pre><code{{with .Language}} class="language-{{.}}"{{end}}>{{.PlainText}}</code></pre>
+{{- with .Caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>
+{{- end -}}
+{{- define "divider"}}<hr>{{end -}}
+{{- define "equation"}}<div class="notion-equation">{{.Expression}}</div>{{end -}}
+
+{{- define "image" -}}
+<figure><img src="{{.URL}}" alt="{{.PlainCaption}}">{{with .Caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>
+{{- end -}}
+{{- define "video" -}}
+<figure><video src="{{.URL}}" controls></video>{{with .Caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>
+{{- end -}}
+{{- define "file"}}<p class="notion-file"><a href="{{.URL}}">{{or .PlainCaption .URL}}</a></p>{{end -}}
+{{- define "pdf"}}<p class="notion-pdf"><a href="{{.URL}}">{{or .PlainCaption .URL}}</a></p>{{end -}}
+{{- define "bookmark"}}<p class="notion-bookmark"><a href="{{.URL}}">{{or .PlainCaption .URL}}</a></p>{{end -}}
+{{- define "embed"}}<p class="notion-embed"><a href="{{.URL}}">{{or .PlainCaption .URL}}</a></p>{{end -}}
+
+{{- define "child_page"}}<p class="notion-page"><a href="{{.URL}}">{{.Title}}</a></p>{{end -}}
+{{- define "child_database"}}<p class="notion-database"><a href="{{.URL}}">{{.Title}}</a></p>{{end -}}
+{{- define "link_to_page"}}<p class="notion-link-to-page"><a href="{{.URL}}">{{.URL}}</a></p>{{end -}}
+
+{{- define "table" -}}
+<table>{{$table := .}}{{range $i, $row := .Rows}}<tr>{{range $j, $cell := $row}}
+{{- if or (and (eq $i 0) $table.HasColumnHeader) (and (eq $j 0) $table.HasRowHeader)}}<th>{{$cell}}</th>
+{{- else}}<td>{{$cell}}</td>{{end}}{{end}}</tr>{{end}}</table>
+{{- end -}}
+{{- define "column_list"}}<div class="notion-columns">{{.Children}}</div>{{end -}}
+{{- define "column"}}<div class="notion-column">{{.Children}}</div>{{end -}}
+{{- define "synced_block"}}{{.Children}}{{end -}}
+`))
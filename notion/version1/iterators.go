@@ -0,0 +1,219 @@
+package version1
+
+import (
+	"context"
+)
+
+// maxPageSize is the largest page_size accepted by Notion's paginated endpoints.
+// https://developers.notion.com/reference/pagination
+const maxPageSize = 100
+
+// iterator follows next_cursor across the pages of a paginated endpoint. The typed iterators below embed it
+// and keep the results of the current page, which their fetch function sets.
+type iterator struct {
+	ctx   context.Context
+	fetch fetchFunc
+
+	// pageSize is the page_size the caller asked for, 0 leaving it to Notion.
+	pageSize int32
+	max      int
+	pages    int
+	seen     int
+	index    int
+	n        int
+	cursor   string
+	more     bool
+	started  bool
+	err      error
+}
+
+// fetchFunc requests the page of results starting at cursor, with at most pageSize results (0 leaves it to Notion).
+// It keeps the results and returns how many there are, along with the response's next_cursor and has_more.
+type fetchFunc func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error)
+
+// newIterator returns an iterator starting at cursor, fetching pages of at most pageSize results with fetch.
+func newIterator(ctx context.Context, cursor string, pageSize int32, fetch fetchFunc) iterator {
+	return iterator{ctx: ctx, cursor: cursor, pageSize: pageSize, fetch: fetch}
+}
+
+// next moves to the next result, fetching the next page when the current one is used up.
+func (it *iterator) next() bool {
+	if it.err != nil || (it.max > 0 && it.seen >= it.max) {
+		return false
+	}
+
+	for it.index+1 >= it.n {
+		if it.started && !it.more {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pages++
+		ctx := context.WithValue(it.ctx, pageKey{}, it.pages)
+		n, next, more, err := it.fetch(ctx, it.cursor, limitPageSize(it.pageSize, it.needed()))
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.n, it.index = n, -1
+		it.cursor, it.more = next, more && next != ""
+	}
+
+	it.index++
+	it.seen++
+	return true
+}
+
+// needed avoids fetching more results than the iterator is allowed to return.
+func (it *iterator) needed() int32 {
+	remaining := it.max - it.seen
+	if it.max <= 0 || remaining >= maxPageSize {
+		return 0
+	}
+	return int32(remaining)
+}
+
+// Err returns the error that stopped the iteration, if any. If the iteration stopped because its context was
+// cancelled, Err returns the context's error.
+func (it *iterator) Err() error {
+	return it.err
+}
+
+type pageKey struct{}
+
+// iteratorPage returns the number of the page an iterator is fetching with ctx, or 0 outside iterators.
+func iteratorPage(ctx context.Context) int {
+	page, _ := ctx.Value(pageKey{}).(int)
+	return page
+}
+
+// limitPageSize lowers requested to what the iterator needs, unless the caller asked for less.
+func limitPageSize(requested, needed int32) int32 {
+	if needed > 0 && (requested == 0 || needed < requested) {
+		return needed
+	}
+	return requested
+}
+
+// UserIter iterates over the users returned by ListUsers.
+//
+//	it := client.Users.ListIter(ctx, nil)
+//	for it.Next() {
+//		user := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle err
+//	}
+type UserIter struct {
+	iterator
+	results []User
+}
+
+// Next moves to the next user. It returns false when there are no more users or an error occurred.
+func (it *UserIter) Next() bool {
+	return it.next()
+}
+
+// Value returns the current user.
+func (it *UserIter) Value() User {
+	return it.results[it.index]
+}
+
+// Limit stops the iteration after n users.
+func (it *UserIter) Limit(n int) *UserIter {
+	it.max = n
+	return it
+}
+
+// DatabaseIter iterates over the databases returned by ListDatabases or SearchDatabase.
+type DatabaseIter struct {
+	iterator
+	results []Database
+}
+
+// Next moves to the next database. It returns false when there are no more databases or an error occurred.
+func (it *DatabaseIter) Next() bool {
+	return it.next()
+}
+
+// Value returns the current database.
+func (it *DatabaseIter) Value() Database {
+	return it.results[it.index]
+}
+
+// Limit stops the iteration after n databases.
+func (it *DatabaseIter) Limit(n int) *DatabaseIter {
+	it.max = n
+	return it
+}
+
+// PageIter iterates over the pages returned by QueryDatabase or SearchPage.
+type PageIter struct {
+	iterator
+	results []Page
+}
+
+// Next moves to the next page. It returns false when there are no more pages or an error occurred.
+func (it *PageIter) Next() bool {
+	return it.next()
+}
+
+// Value returns the current page.
+func (it *PageIter) Value() Page {
+	return it.results[it.index]
+}
+
+// Limit stops the iteration after n pages.
+func (it *PageIter) Limit(n int) *PageIter {
+	it.max = n
+	return it
+}
+
+// BlockIter iterates over the blocks returned by RetrieveBlockChildren.
+type BlockIter struct {
+	iterator
+	results []Block
+}
+
+// Next moves to the next block. It returns false when there are no more blocks or an error occurred.
+func (it *BlockIter) Next() bool {
+	return it.next()
+}
+
+// Value returns the current block.
+func (it *BlockIter) Value() Block {
+	return it.results[it.index]
+}
+
+// Limit stops the iteration after n blocks.
+func (it *BlockIter) Limit(n int) *BlockIter {
+	it.max = n
+	return it
+}
+
+// SearchResultIter iterates over the pages and databases returned by Search.
+type SearchResultIter struct {
+	iterator
+	results []SearchResult
+}
+
+// Next moves to the next result. It returns false when there are no more results or an error occurred.
+func (it *SearchResultIter) Next() bool {
+	return it.next()
+}
+
+// Value returns the current result.
+func (it *SearchResultIter) Value() SearchResult {
+	return it.results[it.index]
+}
+
+// Limit stops the iteration after n results.
+func (it *SearchResultIter) Limit(n int) *SearchResultIter {
+	it.max = n
+	return it
+}
@@ -0,0 +1,133 @@
+package version1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+var (
+	testUsersPageOneJSON = `{"object":"list","results":[{"object":"user","id":"1"},{"object":"user","id":"2"}],"next_cursor":"c2","has_more":true}`
+	testUsersPageTwoJSON = `{"object":"list","results":[{"object":"user","id":"3"}],"next_cursor":null,"has_more":false}`
+	testQueryPageOneJSON = `{"object":"list","results":[{"object":"page","id":"1"}],"next_cursor":"c2","has_more":true}`
+	testQueryPageTwoJSON = `{"object":"list","results":[{"object":"page","id":"2"}],"next_cursor":null,"has_more":false}`
+)
+
+func TestUserService_ListIter(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/users/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("start_cursor") == "c2" {
+			fmt.Fprintf(w, testUsersPageTwoJSON)
+			return
+		}
+		fmt.Fprintf(w, testUsersPageOneJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	it := client.Users.ListIter(context.Background(), nil)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestUserService_ListIter_Limit(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/users/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "2", r.URL.Query().Get("page_size"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUsersPageOneJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	it := client.Users.ListIter(context.Background(), nil).Limit(2)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDatabaseService_QueryIter(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/databases/123/query", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		params := new(notion.QueryDatabaseBodyParams)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(params))
+		assert.NotNil(t, params.Filter)
+
+		w.Header().Set("Content-Type", "application/json")
+		if params.StartCursor == "c2" {
+			fmt.Fprintf(w, testQueryPageTwoJSON)
+			return
+		}
+		fmt.Fprintf(w, testQueryPageOneJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	it := client.Databases.QueryIter(context.Background(), "123", testSingleFilterParams)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, "", testSingleFilterParams.StartCursor)
+}
+
+func TestDatabaseService_QueryIter_ContextCancelled(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/v1/databases/123/query", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testQueryPageOneJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	it := client.Databases.QueryIter(ctx, "123", nil)
+
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+}
+
+func TestBlockService_ChildrenIter_Error(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/blocks/123/children", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, testNotFoundJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	it := client.Blocks.ChildrenIter(context.Background(), "123", nil)
+
+	assert.False(t, it.Next())
+	assert.True(t, notion.IsNotFound(it.Err()))
+}
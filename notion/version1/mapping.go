@@ -0,0 +1,622 @@
+package version1
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	pagePropertyType = reflect.TypeOf(PageProperty{})
+	dateType         = reflect.TypeOf(DateProperty{})
+	stringsType      = reflect.TypeOf([]string(nil))
+)
+
+// readOnlyTypes are the property types Notion computes or that can't be set through the API, which are left
+// out when marshaling.
+var readOnlyTypes = map[string]bool{
+	"files":            true,
+	"formula":          true,
+	"rollup":           true,
+	"created_time":     true,
+	"created_by":       true,
+	"last_edited_time": true,
+	"last_edited_by":   true,
+}
+
+var propertyTypes = map[string]bool{
+	"title": true, "rich_text": true, "number": true, "select": true, "multi_select": true, "date": true,
+	"people": true, "files": true, "checkbox": true, "url": true, "email": true, "phone_number": true,
+	"formula": true, "relation": true, "rollup": true, "created_time": true, "created_by": true,
+	"last_edited_time": true, "last_edited_by": true,
+}
+
+// PropertyTypeError reports a page property that can't be stored in a struct field, or a struct field that
+// can't be sent as a page property.
+type PropertyTypeError struct {
+	Field    string
+	Property string
+	Type     string
+	GoType   reflect.Type
+	Reason   string
+}
+
+func (e *PropertyTypeError) Error() string {
+	msg := fmt.Sprintf("notion: cannot map %v property %q to field %v of type %v", e.Type, e.Property, e.Field,
+		e.GoType)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+type fieldMapping struct {
+	index     []int
+	name      string
+	property  string
+	propType  string
+	omitempty bool
+}
+
+// structFields returns the tagged fields of t, including those of embedded structs.
+func structFields(t reflect.Type) ([]fieldMapping, error) {
+	var fields []fieldMapping
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("notion")
+		if !tagged && sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			embedded, err := structFields(sf.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range embedded {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !tagged || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		f := fieldMapping{index: []int{i}, name: sf.Name, property: parts[0]}
+		if f.property == "" {
+			return nil, fmt.Errorf("notion: field %v has no property name in its tag", sf.Name)
+		}
+		for _, option := range parts[1:] {
+			switch {
+			case option == "omitempty":
+				f.omitempty = true
+			case propertyTypes[option]:
+				f.propType = option
+			default:
+				return nil, fmt.Errorf("notion: field %v has unknown tag option %q", sf.Name, option)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// UnmarshalPage stores the properties of page in the tagged fields of the struct v points to.
+//
+// Fields are mapped to page properties with a tag holding the property name, optionally followed by the
+// property type and "omitempty":
+//
+//	type Task struct {
+//		Name     string    `notion:"Name,title"`
+//		Status   string    `notion:"Status,select"`
+//		Priority float64   `notion:"Priority"`
+//		Done     bool      `notion:"Done"`
+//		Due      time.Time `notion:"Due Date"`
+//		Tags     []string  `notion:"Tags"`
+//		Owners   []string  `notion:"Owner,people"`
+//		Notes    *string   `notion:"Notes,rich_text,omitempty"`
+//	}
+//
+// Fields without a tag, or tagged "-", are ignored. Without a type, strings map to rich_text, numbers to number,
+// bools to checkbox, time.Time to date and []string to multi_select. A field of type PageProperty holds the
+// property as is.
+//
+// Text is read as plain text, selects and multi-selects as option names, people and relations as IDs, files as
+// names, and dates as their start. Formulas and rollups are read as their result.
+// Empty values are stored as zero values, or nil for pointer fields.
+func UnmarshalPage(page *Page, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("notion: UnmarshalPage needs a non-nil pointer to a struct")
+	}
+	return unmarshalPage(page, rv.Elem())
+}
+
+// UnmarshalPages stores pages, e.g. the results of QueryDatabase, in the slice of structs, or of pointers to
+// structs, v points to. See UnmarshalPage.
+func UnmarshalPages(pages []Page, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("notion: UnmarshalPages needs a non-nil pointer to a slice")
+	}
+
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("notion: UnmarshalPages cannot store pages in %v", slice.Type())
+	}
+
+	result := reflect.MakeSlice(slice.Type(), len(pages), len(pages))
+	for i := range pages {
+		elem := result.Index(i)
+		if elemType.Kind() == reflect.Ptr {
+			elem.Set(reflect.New(structType))
+			elem = elem.Elem()
+		}
+		if err := unmarshalPage(&pages[i], elem); err != nil {
+			return err
+		}
+	}
+	slice.Set(result)
+	return nil
+}
+
+func unmarshalPage(page *Page, rv reflect.Value) error {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		prop, ok := page.Properties[f.property]
+		if !ok {
+			return fmt.Errorf("notion: page %v has no property %q for field %v", page.ID, f.property, f.name)
+		}
+
+		dst := rv.FieldByIndex(f.index)
+		typeErr := &PropertyTypeError{Field: f.name, Property: f.property, Type: prop.Type, GoType: dst.Type()}
+		if f.propType != "" && prop.Type != "" && f.propType != prop.Type {
+			typeErr.Reason = fmt.Sprintf("field is tagged %v", f.propType)
+			return typeErr
+		}
+		if dst.Type() == pagePropertyType {
+			dst.Set(reflect.ValueOf(prop))
+			continue
+		}
+
+		value, err := propertyValue(prop)
+		if err != nil {
+			typeErr.Reason = err.Error()
+			return typeErr
+		}
+		if err := setField(dst, value); err != nil {
+			typeErr.Reason = err.Error()
+			return typeErr
+		}
+	}
+	return nil
+}
+
+// propertyValue returns the value of prop as a string, *float64, bool, []string, *DateProperty, or nil when
+// a select, number or date is empty.
+func propertyValue(prop PageProperty) (interface{}, error) {
+	switch prop.Type {
+	case "title":
+		return PlainText(prop.Title), nil
+	case "rich_text":
+		return PlainText(prop.RichText), nil
+	case "number":
+		if prop.Number == nil {
+			return nil, nil
+		}
+		return prop.Number, nil
+	case "select":
+		if prop.Select == nil {
+			return nil, nil
+		}
+		return prop.Select.Name, nil
+	case "multi_select":
+		names := make([]string, len(prop.MultiSelect))
+		for i, option := range prop.MultiSelect {
+			names[i] = option.Name
+		}
+		return names, nil
+	case "date":
+		if prop.Date == nil {
+			return nil, nil
+		}
+		return prop.Date, nil
+	case "checkbox":
+		return prop.Checkbox, nil
+	case "url":
+		return prop.URL, nil
+	case "email":
+		return prop.Email, nil
+	case "phone_number":
+		return prop.PhoneNumber, nil
+	case "people":
+		return userIDs(prop.People), nil
+	case "relation":
+		ids := make([]string, len(prop.Relation))
+		for i, page := range prop.Relation {
+			ids[i] = page.ID
+		}
+		return ids, nil
+	case "files":
+		names := make([]string, len(prop.Files))
+		for i, file := range prop.Files {
+			names[i] = file.Name
+		}
+		return names, nil
+	case "created_time":
+		return &DateProperty{Start: prop.CreatedTime}, nil
+	case "last_edited_time":
+		return &DateProperty{Start: prop.LastEditedTime}, nil
+	case "created_by":
+		if prop.CreatedBy == nil {
+			return nil, nil
+		}
+		return prop.CreatedBy.ID, nil
+	case "last_edited_by":
+		if prop.LastEditedBy == nil {
+			return nil, nil
+		}
+		return prop.LastEditedBy.ID, nil
+	case "formula":
+		if prop.Formula != nil {
+			return formulaValue(prop.Formula)
+		}
+		return nil, nil
+	case "rollup":
+		if prop.Rollup != nil {
+			return rollupValue(prop.Rollup)
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported property type %q", prop.Type)
+}
+
+func formulaValue(formula *FormulaProperty) (interface{}, error) {
+	switch formula.Type {
+	case "string":
+		return formula.String, nil
+	case "number":
+		if formula.Number == nil {
+			return nil, nil
+		}
+		return formula.Number, nil
+	case "boolean":
+		return formula.Boolean, nil
+	case "date":
+		if formula.Date == nil {
+			return nil, nil
+		}
+		return formula.Date, nil
+	}
+	return nil, fmt.Errorf("unsupported formula type %q", formula.Type)
+}
+
+func rollupValue(rollup *RollupProperty) (interface{}, error) {
+	switch rollup.Type {
+	case "number":
+		if rollup.Number == nil {
+			return nil, nil
+		}
+		return rollup.Number, nil
+	case "date":
+		if rollup.Date == nil {
+			return nil, nil
+		}
+		return rollup.Date, nil
+	}
+	return nil, fmt.Errorf("unsupported rollup type %q", rollup.Type)
+}
+
+// setField stores value, as returned by propertyValue, in dst.
+func setField(dst reflect.Value, value interface{}) error {
+	if dst.Kind() == reflect.Ptr {
+		if value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		elem := reflect.New(dst.Type().Elem())
+		if err := setField(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch dst.Type() {
+	case timeType:
+		date, ok := value.(*DateProperty)
+		if !ok {
+			return errMismatch
+		}
+		t, err := parseTime(date.Start)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	case dateType:
+		date, ok := value.(*DateProperty)
+		if !ok {
+			return errMismatch
+		}
+		dst.Set(reflect.ValueOf(*date))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		switch value := value.(type) {
+		case string:
+			dst.SetString(value)
+			return nil
+		case *DateProperty:
+			dst.SetString(value.Start)
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, ok := value.(*float64); ok {
+			dst.SetFloat(*n)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := value.(*float64); ok {
+			i := int64(*n)
+			if float64(i) != *n || dst.OverflowInt(i) {
+				return fmt.Errorf("%v doesn't fit", *n)
+			}
+			dst.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := value.(*float64); ok {
+			u := uint64(*n)
+			if *n < 0 || float64(u) != *n || dst.OverflowUint(u) {
+				return fmt.Errorf("%v doesn't fit", *n)
+			}
+			dst.SetUint(u)
+			return nil
+		}
+	case reflect.Slice:
+		if values, ok := value.([]string); ok && dst.Type().Elem().Kind() == reflect.String {
+			slice := reflect.MakeSlice(dst.Type(), len(values), len(values))
+			for i, v := range values {
+				slice.Index(i).SetString(v)
+			}
+			dst.Set(slice)
+			return nil
+		}
+	}
+	return errMismatch
+}
+
+// errMismatch is the reason of a PropertyTypeError whose types don't match. It's left out of the message.
+var errMismatch = errors.New("")
+
+// parseTime parses a Notion date, which is either a date or a date and time.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// formatTime formats t as a date if it is midnight UTC, and as a date and time otherwise.
+func formatTime(t time.Time) string {
+	if t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func userIDs(users []User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// MarshalProperties returns the tagged fields of v, a struct or a pointer to one, as page properties.
+// Nil pointers and, with omitempty, zero values are left out. Other zero values are sent, so a zero field
+// unchecks a checkbox or clears a date. Properties Notion computes, like formulas, and files are always left
+// out, as are PageProperty fields without a Type.
+func MarshalProperties(v interface{}) (map[string]PageProperty, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notion: MarshalProperties needs a struct, not %T", v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]PageProperty)
+	for _, f := range fields {
+		value := rv.FieldByIndex(f.index)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if f.omitempty && value.IsZero() {
+			continue
+		}
+
+		if value.Type() == pagePropertyType {
+			prop := value.Interface().(PageProperty)
+			if prop.Type != "" && !readOnlyTypes[prop.Type] && !readOnlyTypes[f.propType] {
+				properties[f.property] = prop
+			}
+			continue
+		}
+
+		propType := f.propType
+		if propType == "" {
+			propType = defaultPropertyType(value.Type())
+		}
+		if readOnlyTypes[propType] {
+			continue
+		}
+
+		prop, ok := propertyFromValue(propType, value)
+		if !ok {
+			return nil, &PropertyTypeError{Field: f.name, Property: f.property, Type: propType, GoType: value.Type()}
+		}
+		properties[f.property] = prop
+	}
+	return properties, nil
+}
+
+// MarshalCreatePageParams returns the params to create a page under parent with the tagged fields of v as its
+// properties. See MarshalProperties.
+func MarshalCreatePageParams(parent *Parent, v interface{}) (*CreatePageBodyParams, error) {
+	properties, err := MarshalProperties(v)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatePageBodyParams{Parent: parent, Properties: properties}, nil
+}
+
+// MarshalUpdatePageParams returns the params to set the properties of a page to the tagged fields of v.
+// See MarshalProperties.
+func MarshalUpdatePageParams(v interface{}) (*UpdatePagePropertiesBodyParams, error) {
+	properties, err := MarshalProperties(v)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatePagePropertiesBodyParams{Properties: properties}, nil
+}
+
+// defaultPropertyType returns the property type of fields of type t without a type in their tag.
+func defaultPropertyType(t reflect.Type) string {
+	switch {
+	case t == timeType || t == dateType:
+		return "date"
+	case t == stringsType:
+		return "multi_select"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "rich_text"
+	case reflect.Bool:
+		return "checkbox"
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "number"
+	}
+	return ""
+}
+
+// propertyFromValue returns value as a property of propType, or false if it can't be one.
+func propertyFromValue(propType string, value reflect.Value) (PageProperty, bool) {
+	prop := PageProperty{Type: propType}
+
+	switch propType {
+	case "title", "rich_text", "select", "url", "email", "phone_number":
+		if value.Kind() != reflect.String {
+			return prop, false
+		}
+		s := value.String()
+		switch propType {
+		case "title":
+			prop.Title = textRichText(s)
+		case "rich_text":
+			prop.RichText = textRichText(s)
+		case "select":
+			if s != "" {
+				prop.Select = &SelectProperty{Name: s}
+			}
+		case "url":
+			prop.URL = s
+		case "email":
+			prop.Email = s
+		case "phone_number":
+			prop.PhoneNumber = s
+		}
+	case "number":
+		switch value.Kind() {
+		case reflect.Float32, reflect.Float64:
+			prop.Number = Float64(value.Float())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			prop.Number = Float64(float64(value.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			prop.Number = Float64(float64(value.Uint()))
+		default:
+			return prop, false
+		}
+	case "checkbox":
+		if value.Kind() != reflect.Bool {
+			return prop, false
+		}
+		prop.Checkbox = value.Bool()
+	case "date":
+		switch {
+		case value.Type() == timeType:
+			if t := value.Interface().(time.Time); !t.IsZero() {
+				prop.Date = &DateProperty{Start: formatTime(t)}
+			}
+		case value.Type() == dateType:
+			if date := value.Interface().(DateProperty); date.Start != "" {
+				prop.Date = &date
+			}
+		case value.Kind() == reflect.String:
+			if s := value.String(); s != "" {
+				prop.Date = &DateProperty{Start: s}
+			}
+		default:
+			return prop, false
+		}
+	case "multi_select", "people", "relation":
+		if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.String {
+			return prop, false
+		}
+		for i := 0; i < value.Len(); i++ {
+			s := value.Index(i).String()
+			switch propType {
+			case "multi_select":
+				prop.MultiSelect = append(prop.MultiSelect, MultiSelectPropertyOpts{Name: s})
+			case "people":
+				prop.People = append(prop.People, User{Object: "user", ID: s})
+			case "relation":
+				prop.Relation = append(prop.Relation, PageReferenceProperty{ID: s})
+			}
+		}
+	default:
+		return prop, false
+	}
+	return prop, true
+}
+
+// textRichText returns s as text, split into as many rich text objects as Notion's length limit requires.
+func textRichText(s string) []RichText {
+	if s == "" {
+		return nil
+	}
+	return SplitText(RichText{Type: "text", Text: &Text{Content: s}})
+}
@@ -0,0 +1,186 @@
+package version1_test
+
+import (
+	"encoding/json"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testTask struct {
+	Name     string    `notion:"Name,title"`
+	Status   string    `notion:"Status,select"`
+	Priority float64   `notion:"Priority"`
+	Estimate *int      `notion:"Estimate,number"`
+	Done     bool      `notion:"Done"`
+	Due      time.Time `notion:"Due"`
+	Tags     []string  `notion:"Tags"`
+	Owners   []string  `notion:"Owner,people"`
+	Notes    *string   `notion:"Notes,rich_text,omitempty"`
+	Score    float64   `notion:"Score,formula"`
+	Ignored  string
+}
+
+var testTaskPage = &notion.Page{
+	Object: "page",
+	ID:     "p1",
+	Properties: map[string]notion.PageProperty{
+		"Name": {Type: "title", Title: []notion.RichText{
+			{PlainText: "Write "}, {Type: "text", Text: &notion.Text{Content: "docs"}},
+		}},
+		"Status":   {Type: "select", Select: &notion.SelectProperty{Name: "Doing"}},
+		"Priority": {Type: "number", Number: notion.Float64(2.5)},
+		"Estimate": {Type: "number"},
+		"Done":     {Type: "checkbox", Checkbox: true},
+		"Due":      {Type: "date", Date: &notion.DateProperty{Start: "2021-05-10"}},
+		"Tags":     {Type: "multi_select", MultiSelect: []notion.MultiSelectPropertyOpts{{Name: "a"}, {Name: "b"}}},
+		"Owner":    {Type: "people", People: []notion.User{{ID: "u1"}}},
+		"Notes":    {Type: "rich_text", RichText: []notion.RichText{{PlainText: "n"}}},
+		"Score": {Type: "formula", Formula: &notion.FormulaProperty{Type: "number",
+			Number: notion.Float64(7)}},
+	},
+}
+
+func TestUnmarshalPage(t *testing.T) {
+	var task testTask
+	err := notion.UnmarshalPage(testTaskPage, &task)
+	assert.Nil(t, err)
+
+	notes := "n"
+	assert.Equal(t, testTask{
+		Name:     "Write docs",
+		Status:   "Doing",
+		Priority: 2.5,
+		Done:     true,
+		Due:      time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC),
+		Tags:     []string{"a", "b"},
+		Owners:   []string{"u1"},
+		Notes:    &notes,
+		Score:    7,
+	}, task)
+}
+
+func TestUnmarshalPages(t *testing.T) {
+	var tasks []*testTask
+	err := notion.UnmarshalPages([]notion.Page{*testTaskPage, *testTaskPage}, &tasks)
+	assert.Nil(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "Write docs", tasks[1].Name)
+}
+
+func TestUnmarshalPage_Errors(t *testing.T) {
+	var wrongType struct {
+		Priority string `notion:"Priority"`
+	}
+	err := notion.UnmarshalPage(testTaskPage, &wrongType)
+
+	var typeErr *notion.PropertyTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "notion: cannot map number property \"Priority\" to field Priority of type string", err.Error())
+
+	var wrongTag struct {
+		Status string `notion:"Status,rich_text"`
+	}
+	err = notion.UnmarshalPage(testTaskPage, &wrongTag)
+	assert.Equal(t, "notion: cannot map select property \"Status\" to field Status of type string: "+
+		"field is tagged rich_text", err.Error())
+
+	var fraction struct {
+		Priority int `notion:"Priority"`
+	}
+	err = notion.UnmarshalPage(testTaskPage, &fraction)
+	assert.Equal(t, "notion: cannot map number property \"Priority\" to field Priority of type int: "+
+		"2.5 doesn't fit", err.Error())
+
+	var missing struct {
+		Name string `notion:"Nmae"`
+	}
+	err = notion.UnmarshalPage(testTaskPage, &missing)
+	assert.Equal(t, "notion: page p1 has no property \"Nmae\" for field Name", err.Error())
+
+	assert.NotNil(t, notion.UnmarshalPage(testTaskPage, missing))
+}
+
+func TestMarshalProperties(t *testing.T) {
+	task := testTask{
+		Name:   "Write docs",
+		Tags:   []string{"a"},
+		Owners: []string{"u1"},
+		Due:    time.Date(2021, 5, 10, 9, 30, 0, 0, time.UTC),
+		Score:  7,
+	}
+
+	params, err := notion.MarshalUpdatePageParams(&task)
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(params)
+	assert.Nil(t, err)
+	// Zero values clear their property, while the nil Estimate and Notes and the formula Score are left out.
+	assert.Equal(t, `{"properties":{`+
+		`"Done":{"type":"checkbox","checkbox":false},`+
+		`"Due":{"type":"date","date":{"start":"2021-05-10T09:30:00Z"}},`+
+		`"Name":{"type":"title","title":[{"type":"text","text":{"content":"Write docs"}}]},`+
+		`"Owner":{"type":"people","people":[{"object":"user","id":"u1"}]},`+
+		`"Priority":{"type":"number","number":0},`+
+		`"Status":{"type":"select","select":null},`+
+		`"Tags":{"type":"multi_select","multi_select":[{"name":"a"}]}}}`, string(data))
+}
+
+func TestMarshalCreatePageParams(t *testing.T) {
+	params, err := notion.MarshalCreatePageParams(notion.NewDatabaseParent("db"), struct {
+		Name string `notion:"Name,title"`
+	}{strings.Repeat("é", 2001)})
+	assert.Nil(t, err)
+
+	properties := params.Properties.(map[string]notion.PageProperty)
+	assert.Equal(t, "db", params.Parent.Database.DatabaseID)
+	assert.Len(t, properties["Name"].Title, 2)
+	assert.Equal(t, "é", properties["Name"].Title[1].Text.Content)
+}
+
+func TestMarshalProperties_Errors(t *testing.T) {
+	_, err := notion.MarshalProperties(struct {
+		Done string `notion:"Done,checkbox"`
+	}{})
+
+	var typeErr *notion.PropertyTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "notion: cannot map checkbox property \"Done\" to field Done of type string", err.Error())
+
+	_, err = notion.MarshalProperties(struct {
+		Done bool `notion:"Done,boolean"`
+	}{})
+	assert.Equal(t, "notion: field Done has unknown tag option \"boolean\"", err.Error())
+}
+
+func TestMarshalProperties_ReadOnly(t *testing.T) {
+	properties, err := notion.MarshalProperties(struct {
+		Score notion.PageProperty `notion:"Score,formula"`
+		Files []string            `notion:"Files,files"`
+		Notes notion.PageProperty `notion:"Notes"`
+	}{
+		Score: notion.PageProperty{Type: "formula", Formula: &notion.FormulaProperty{Type: "string", String: "x"}},
+		Files: []string{"a.pdf"},
+		Notes: notion.ClearProperty("rich_text"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]notion.PageProperty{"Notes": {Type: "rich_text"}}, properties)
+}
+
+func TestMarshalCreatePageParams_ZeroReadOnly(t *testing.T) {
+	params, err := notion.MarshalCreatePageParams(notion.NewDatabaseParent("db"), struct {
+		Name  string              `notion:"Name,title"`
+		Score notion.PageProperty `notion:"Score,formula"`
+		Total notion.PageProperty `notion:"Total,rollup"`
+		Notes notion.PageProperty `notion:"Notes"`
+	}{Name: "a"})
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(params)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"parent":{"type":"database_id","database_id":"db"},`+
+		`"properties":{"Name":{"type":"title","title":[{"type":"text","text":{"content":"a"}}]}}}`, string(data))
+}
@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+)
+
+// maxNesting is how many levels of children Notion accepts below the children of one request.
+const maxNesting = 2
+
+// Append appends blocks, e.g. from Parse, to the children of the page or block with id, in as many requests as
+// Notion's limits require: at most 100 blocks in each children list, nested at most two levels deep. Children that
+// don't fit in a request are appended once their parent exists. Their parent is found among the children of id, so
+// nothing else should append to id at the same time.
+func Append(ctx context.Context, service *notion.BlockService, id string, blocks []notion.Block) error {
+	for _, batch := range Batches(blocks) {
+		sent := make([]notion.Block, len(batch))
+		later := make([][]notion.Block, len(batch))
+		deferred := false
+		for i, block := range batch {
+			sent[i] = block
+			if children := block.Children(); len(children) > 0 && !fits(children, 1) {
+				// Children are held by the block's content, which the caller's block shares.
+				var err error
+				if sent[i], err = withoutChildren(block); err != nil {
+					return err
+				}
+				later[i], deferred = children, true
+			}
+		}
+
+		params := &notion.AppendBlockChildrenBodyParams{Children: sent}
+		if _, _, err := service.AppendBlockChildren(ctx, id, params); err != nil {
+			return err
+		}
+		if !deferred {
+			continue
+		}
+
+		ids, err := lastChildren(ctx, service, id, len(batch))
+		if err != nil {
+			return err
+		}
+		for i, children := range later {
+			if children == nil {
+				continue
+			}
+			if err := Append(ctx, service, ids[i], children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fits reports whether children, nested depth levels below the children of a request, can be sent in it.
+func fits(children []notion.Block, depth int) bool {
+	if len(children) > maxChildren {
+		return false
+	}
+	for _, block := range children {
+		if grandchildren := block.Children(); len(grandchildren) > 0 &&
+			(depth >= maxNesting || !fits(grandchildren, depth+1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutChildren returns a copy of block without its children, leaving block as it is.
+func withoutChildren(block notion.Block) (notion.Block, error) {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return notion.Block{}, err
+	}
+	var copied notion.Block
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return notion.Block{}, err
+	}
+	copied.SetChildren(nil)
+	return copied, nil
+}
+
+// lastChildren returns the IDs of the last n children of the page or block with id.
+func lastChildren(ctx context.Context, service *notion.BlockService, id string, n int) ([]string, error) {
+	var ids []string
+	it := service.ChildrenIter(ctx, id, nil)
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) < n {
+		return nil, fmt.Errorf("markdown: %v has %d children after appending %d", id, len(ids), n)
+	}
+	return ids[len(ids)-n:], nil
+}
@@ -0,0 +1,68 @@
+package markdown_test
+
+import (
+	"context"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/markdown"
+	"github.com/oyekanmiayo/go-notion/notion/version1/notiontest"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func item(text string, children ...notion.Block) notion.Block {
+	return block("bulleted_list_item", func(b *notion.Block) {
+		b.BulletedListItem = &notion.BulletedListItemBlock{
+			Text:     []notion.RichText{{Type: "text", Text: &notion.Text{Content: text}}},
+			Children: children,
+		}
+	})
+}
+
+func TestAppend(t *testing.T) {
+	server := notiontest.NewServer()
+	defer server.Close()
+	page := server.AddPage(notion.Page{Parent: notion.NewWorkspaceParent()})
+	client := server.Client()
+
+	var many []notion.Block
+	for i := 0; i < 150; i++ {
+		many = append(many, item(fmt.Sprintf("sub %d", i)))
+	}
+	deep := item("1", item("2", item("3", item("4", item("5")))))
+	blocks := []notion.Block{item("many", many...), deep}
+	for i := 0; i < 120; i++ {
+		blocks = append(blocks, item(fmt.Sprintf("item %d", i), item("nested")))
+	}
+
+	// The server rejects the blocks as they are.
+	_, _, err := client.Blocks.AppendBlockChildren(context.Background(), page.ID,
+		&notion.AppendBlockChildrenBodyParams{Children: blocks[:2]})
+	assert.True(t, notion.IsValidationError(err))
+
+	assert.Nil(t, markdown.Append(context.Background(), client.Blocks, page.ID, blocks))
+
+	tree := server.Blocks(page.ID)
+	if assert.Len(t, tree, 122) {
+		children := tree[0].Children()
+		if assert.Len(t, children, 150) {
+			assert.Equal(t, "sub 149", notion.PlainText(children[149].BulletedListItem.Text))
+		}
+
+		var levels []string
+		for b := tree[1]; ; b = b.Children()[0] {
+			levels = append(levels, notion.PlainText(b.BulletedListItem.Text))
+			if len(b.Children()) == 0 {
+				break
+			}
+		}
+		assert.Equal(t, "1 2 3 4 5", strings.Join(levels, " "))
+		assert.Equal(t, "item 119", notion.PlainText(tree[121].BulletedListItem.Text))
+		assert.Len(t, tree[121].Children(), 1)
+	}
+
+	// The caller's blocks keep their children.
+	assert.Len(t, blocks[0].Children(), 150)
+	assert.Len(t, deep.Children(), 1)
+}
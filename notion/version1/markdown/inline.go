@@ -0,0 +1,173 @@
+package markdown
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"regexp"
+	"strings"
+)
+
+// RenderRichText renders rich text as inline Markdown: annotations as emphasis, code spans and strikethrough, links
+// as links and equations as $inline math$. Underline and colors have no Markdown form and are left out.
+func (r *Renderer) RenderRichText(richText []notion.RichText) string {
+	var b strings.Builder
+	for _, rt := range merge(richText) {
+		switch rt.Type {
+		case "equation":
+			if rt.Equation != nil {
+				b.WriteString("$" + rt.Equation.Expression + "$")
+			}
+		case "mention":
+			if r.Mention != nil {
+				b.WriteString(r.Mention(rt))
+			} else {
+				b.WriteString(mention(rt))
+			}
+		default:
+			b.WriteString(text(rt))
+		}
+	}
+
+	// A line break at the end would render as a stray backslash.
+	out := b.String()
+	for strings.HasSuffix(out, "\\\n") {
+		out = strings.TrimSuffix(out, "\\\n")
+	}
+	return out
+}
+
+// merge joins consecutive pieces of text with the same annotations and link, so that e.g. two bold pieces render as
+// one **bold** span rather than an invalid **a****b**.
+func merge(richText []notion.RichText) []notion.RichText {
+	var merged []notion.RichText
+	for _, rt := range richText {
+		if rt.Type != "" && rt.Type != "text" {
+			merged = append(merged, rt)
+			continue
+		}
+
+		content, url := rt.Content(), textURL(rt)
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if (last.Type == "" || last.Type == "text") && textURL(*last) == url &&
+				sameStyle(last.Annotations, rt.Annotations) {
+				last.Text = &notion.Text{Content: last.Content() + content, Link: last.Text.Link}
+				continue
+			}
+		}
+
+		rt.Text = &notion.Text{Content: content}
+		if url != "" {
+			rt.Text.Link = &notion.Link{URL: url}
+		}
+		merged = append(merged, rt)
+	}
+	return merged
+}
+
+func textURL(rt notion.RichText) string {
+	if rt.Text != nil && rt.Text.Link != nil {
+		return rt.Text.Link.URL
+	}
+	return rt.Href
+}
+
+// sameStyle reports whether a and b render the same, ignoring the annotations Markdown can't show.
+func sameStyle(a, b *notion.Annotations) bool {
+	if a == nil {
+		a = &notion.Annotations{}
+	}
+	if b == nil {
+		b = &notion.Annotations{}
+	}
+	return a.Bold == b.Bold && a.Italic == b.Italic && a.Strikethrough == b.Strikethrough && a.Code == b.Code
+}
+
+func text(rt notion.RichText) string {
+	content := rt.Content()
+	// Emphasis can't start or end with whitespace, so it goes around the text between any.
+	core := strings.TrimSpace(content)
+	if core == "" {
+		return content
+	}
+	start := strings.Index(content, core)
+	lead, trail := content[:start], content[start+len(core):]
+
+	a := rt.Annotations
+	if a == nil {
+		a = &notion.Annotations{}
+	}
+	if a.Code {
+		core = codeSpan(core)
+	} else {
+		core = escape(core)
+	}
+	if a.Strikethrough {
+		core = "~~" + core + "~~"
+	}
+	if a.Italic {
+		core = "*" + core + "*"
+	}
+	if a.Bold {
+		core = "**" + core + "**"
+	}
+	if url := rt.Text.Link; url != nil {
+		core = "[" + core + "](" + linkDestination(url.URL) + ")"
+	}
+	return lineBreaks(lead) + core + lineBreaks(trail)
+}
+
+// mention renders a mention as its plain text, linked to the mentioned page or database.
+func mention(rt notion.RichText) string {
+	text := escape(rt.PlainText)
+	if rt.Href != "" {
+		return link(text, rt.Href)
+	}
+	if m := rt.Mention; m != nil {
+		switch {
+		case m.PageMention != nil:
+			return link(text, notion.PageURL(m.PageMention.ID))
+		case m.DatabaseMention != nil:
+			return link(text, notion.PageURL(m.DatabaseMention.ID))
+		}
+	}
+	return text
+}
+
+// codeSpan wraps s in enough backticks that s can't close the span.
+func codeSpan(s string) string {
+	fence := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "~", `\~`,
+)
+
+// blockMarker matches what would start a heading, list item or thematic break at the start of a line. Quote markers
+// and the other thematic breaks are already escaped by escaper.
+var blockMarker = regexp.MustCompile(`^ *(#|[-+](?:[ \t]|$)|(?:-[ \t]*){3,}$|\d{1,9}[.)](?:[ \t]|$))`)
+
+// escape escapes the characters of s that Markdown would read as formatting, and turns newlines into hard line
+// breaks.
+func escape(s string) string {
+	lines := strings.Split(escaper.Replace(s), "\n")
+	for i, line := range lines {
+		if m := blockMarker.FindStringSubmatchIndex(line); m != nil {
+			// Numbers can't be escaped, so the "." or ")" after them is.
+			at := m[2]
+			if c := line[at]; c >= '0' && c <= '9' {
+				at += strings.IndexAny(line[at:], ".)")
+			}
+			lines[i] = line[:at] + `\` + line[at:]
+		}
+	}
+	return lineBreaks(strings.Join(lines, "\n"))
+}
+
+// lineBreaks turns the newlines of s into hard line breaks.
+func lineBreaks(s string) string {
+	return strings.ReplaceAll(s, "\n", "\\\n")
+}
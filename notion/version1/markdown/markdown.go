@@ -0,0 +1,332 @@
+// Package markdown renders Notion blocks and rich text as GitHub Flavored Markdown, and parses Markdown into blocks.
+//
+// It renders the blocks returned by BlockService.RetrieveBlockTree, so nested lists, toggles and quotes keep their
+// children:
+//
+//	blocks, err := client.Blocks.RetrieveBlockTree(ctx, pageID, nil)
+//	if err != nil {
+//		// handle err
+//	}
+//	fmt.Print(markdown.Render(blocks))
+//
+// Parse goes the other way, turning Markdown into blocks to create pages or append children with.
+package markdown
+
+import (
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"strings"
+)
+
+// Renderer renders blocks as Markdown. The zero value is ready to use; set its hooks to change how some blocks and
+// mentions render.
+type Renderer struct {
+	// ChildPage renders child_page and child_database blocks.
+	// By default, they render as a link to the page on notion.so.
+	ChildPage func(block notion.Block) string
+
+	// Unsupported renders blocks that have no Markdown form, e.g. table_of_contents, breadcrumb, template and
+	// unsupported blocks, and blocks of unknown types. By default, they are left out.
+	Unsupported func(block notion.Block) string
+
+	// Mention renders mentions of users, pages, databases and dates.
+	// By default, they render as their plain text, linked to the mentioned page or database.
+	Mention func(mention notion.RichText) string
+}
+
+// Render renders blocks as Markdown with the default Renderer.
+func Render(blocks []notion.Block) string {
+	return (&Renderer{}).Render(blocks)
+}
+
+// RenderRichText renders rich text as inline Markdown with the default Renderer.
+func RenderRichText(richText []notion.RichText) string {
+	return (&Renderer{}).RenderRichText(richText)
+}
+
+// Render renders blocks as Markdown, ending with a newline unless there is nothing to render.
+func (r *Renderer) Render(blocks []notion.Block) string {
+	out := r.blocks(blocks)
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+// blocks renders blocks separated by blank lines, or by single newlines between the items of a list.
+func (r *Renderer) blocks(blocks []notion.Block) string {
+	var b strings.Builder
+	var previous string
+	number := 0
+	for i := range blocks {
+		block := &blocks[i]
+		if block.Type == "numbered_list_item" {
+			number++
+		} else {
+			number = 0
+		}
+
+		out := r.block(block, number)
+		if out == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			if kind := listKind(block.Type); kind != "" && kind == listKind(previous) {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(out)
+		previous = block.Type
+	}
+	return b.String()
+}
+
+// listKind returns the list marker of blocks of blockType, or "" if they aren't list items.
+func listKind(blockType string) string {
+	switch blockType {
+	case "bulleted_list_item", "to_do":
+		return "-"
+	case "numbered_list_item":
+		return "1."
+	}
+	return ""
+}
+
+// block renders block; number is its position in a numbered list.
+func (r *Renderer) block(block *notion.Block, number int) string {
+	switch {
+	case block.Paragraph != nil:
+		return joinBlocks(r.RenderRichText(block.Paragraph.Text), r.blocks(block.Paragraph.Children))
+	case block.HeadingOne != nil:
+		return "# " + r.RenderRichText(block.HeadingOne.Text)
+	case block.HeadingTwo != nil:
+		return "## " + r.RenderRichText(block.HeadingTwo.Text)
+	case block.HeadingThree != nil:
+		return "### " + r.RenderRichText(block.HeadingThree.Text)
+	case block.BulletedListItem != nil:
+		return r.listItem("- ", block.BulletedListItem.Text, block.BulletedListItem.Children)
+	case block.NumberedListItem != nil:
+		return r.listItem(fmt.Sprintf("%d. ", number), block.NumberedListItem.Text, block.NumberedListItem.Children)
+	case block.ToDo != nil:
+		marker := "- [ ] "
+		if block.ToDo.Checked {
+			marker = "- [x] "
+		}
+		return r.listItem(marker, block.ToDo.Text, block.ToDo.Children)
+	case block.Toggle != nil:
+		return r.toggle(block.Toggle)
+	case block.Quote != nil:
+		return quote(joinBlocks(r.RenderRichText(block.Quote.Text), r.blocks(block.Quote.Children)))
+	case block.Callout != nil:
+		text := r.RenderRichText(block.Callout.Text)
+		if icon := block.Callout.Icon; icon != nil && icon.Emoji != "" {
+			text = icon.Emoji + " " + text
+		}
+		return quote(joinBlocks(text, r.blocks(block.Callout.Children)))
+	case block.Code != nil:
+		return codeBlock(notion.PlainText(block.Code.Text), block.Code.Language)
+	case block.Divider != nil:
+		return "---"
+	case block.Equation != nil:
+		return "$$\n" + block.Equation.Expression + "\n$$"
+	case block.Image != nil:
+		return "![" + escape(notion.PlainText(block.Image.Caption)) + "](" + linkDestination(block.Image.URL()) + ")"
+	case block.Video != nil:
+		return fileLink(block.Video)
+	case block.File != nil:
+		return fileLink(block.File)
+	case block.PDF != nil:
+		return fileLink(block.PDF)
+	case block.Bookmark != nil:
+		return link(r.RenderRichText(block.Bookmark.Caption), block.Bookmark.URL)
+	case block.Embed != nil:
+		return link(r.RenderRichText(block.Embed.Caption), block.Embed.URL)
+	case block.Table != nil:
+		return r.table(block.Table)
+	case block.ColumnList != nil:
+		return r.blocks(block.ColumnList.Children)
+	case block.Column != nil:
+		return r.blocks(block.Column.Children)
+	case block.SyncedBlock != nil:
+		return r.blocks(block.SyncedBlock.Children)
+	case block.LinkToPage != nil:
+		id := block.LinkToPage.PageID
+		if id == "" {
+			id = block.LinkToPage.DatabaseID
+		}
+		return link("", notion.PageURL(id))
+	case block.ChildPage != nil:
+		if r.ChildPage != nil {
+			return r.ChildPage(*block)
+		}
+		return link(escape(block.ChildPage.Title), notion.PageURL(block.ID))
+	case block.ChildDatabase != nil:
+		if r.ChildPage != nil {
+			return r.ChildPage(*block)
+		}
+		return link(escape(block.ChildDatabase.Title), notion.PageURL(block.ID))
+	}
+
+	if r.Unsupported != nil {
+		return r.Unsupported(*block)
+	}
+	return ""
+}
+
+// listItem renders a list item starting with marker, with its text and children indented under the marker.
+func (r *Renderer) listItem(marker string, text []notion.RichText, children []notion.Block) string {
+	out := marker + r.RenderRichText(text)
+	if nested := r.blocks(children); nested != "" {
+		// Children that aren't list items need a blank line, or they would continue the item's paragraph.
+		if listKind(children[0].Type) != "" {
+			out += "\n" + nested
+		} else {
+			out += "\n\n" + nested
+		}
+	}
+	// Continuation lines line up with the text after the list marker, e.g. "- " of a to-do's "- [ ] ".
+	return indent(out, strings.Repeat(" ", strings.Index(marker, " ")+1))
+}
+
+func (r *Renderer) toggle(toggle *notion.ToggleBlock) string {
+	out := "<details>\n<summary>" + r.RenderRichText(toggle.Text) + "</summary>\n"
+	if children := r.blocks(toggle.Children); children != "" {
+		out += "\n" + children + "\n"
+	}
+	return out + "\n</details>"
+}
+
+// table renders a table, using its first row as the header, which GFM requires.
+func (r *Renderer) table(table *notion.TableBlock) string {
+	var rows [][]string
+	width := table.TableWidth
+	for _, row := range table.Children {
+		if row.TableRow == nil {
+			continue
+		}
+		cells := make([]string, len(row.TableRow.Cells))
+		for i, cell := range row.TableRow.Cells {
+			cells[i] = strings.NewReplacer("|", `\|`, "\\\n", "<br>").Replace(r.RenderRichText(cell))
+		}
+		if len(cells) > width {
+			width = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("|")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		if i == 0 {
+			b.WriteString("\n|" + strings.Repeat(" --- |", width))
+		}
+	}
+	return b.String()
+}
+
+// joinBlocks joins the rendered text of a block and its children, leaving out what is empty.
+func joinBlocks(text, children string) string {
+	switch {
+	case text == "":
+		return children
+	case children == "":
+		return text
+	}
+	return text + "\n\n" + children
+}
+
+// indent indents the lines of s after the first with prefix, leaving blank lines empty.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quote prefixes every line of s with "> ".
+func quote(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// codeBlock fences code with enough backticks that the code can't close the fence.
+func codeBlock(code, language string) string {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	if language == "plain text" {
+		language = ""
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+func fileLink(file *notion.FileBlock) string {
+	text := escape(notion.PlainText(file.Caption))
+	if text == "" {
+		url := file.URL()
+		if i := strings.IndexAny(url, "?#"); i >= 0 {
+			url = url[:i]
+		}
+		text = escape(url[strings.LastIndex(url, "/")+1:])
+	}
+	return link(text, file.URL())
+}
+
+// link renders a link to url with text, or with url as its text if text is empty.
+func link(text, url string) string {
+	if text == "" {
+		text = escape(url)
+	}
+	return "[" + text + "](" + linkDestination(url) + ")"
+}
+
+// linkDestination wraps url in angle brackets if it has characters that would end the link early.
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+func longestRun(s string, c rune) int {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,148 @@
+package markdown_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/markdown"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func text(content string) []notion.RichText {
+	return []notion.RichText{{Type: "text", Text: &notion.Text{Content: content}}}
+}
+
+func styled(content string, annotations notion.Annotations) notion.RichText {
+	return notion.RichText{Type: "text", Text: &notion.Text{Content: content}, Annotations: &annotations}
+}
+
+var testBlocks = []notion.Block{
+	{Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{Text: text("Title")}},
+	{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: []notion.RichText{
+		{Type: "text", Text: &notion.Text{Content: "Some "}},
+		styled("bold ", notion.Annotations{Bold: true}),
+		styled("text", notion.Annotations{Bold: true, Color: "red"}),
+		{Type: "text", Text: &notion.Text{Content: ", "}},
+		styled("code", notion.Annotations{Code: true}),
+		{Type: "text", Text: &notion.Text{Content: ", "}},
+		styled("gone", notion.Annotations{Strikethrough: true, Italic: true}),
+		{Type: "text", Text: &notion.Text{Content: " and a "}},
+		{Type: "text", Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com"}}},
+		{Type: "text", Text: &notion.Text{Content: " to 2*3_[x]."}},
+	}}},
+	{Type: "bulleted_list_item", BulletedListItem: &notion.BulletedListItemBlock{
+		Text: text("One"),
+		Children: []notion.Block{
+			{Type: "numbered_list_item", NumberedListItem: &notion.NumberedListItemBlock{Text: text("Nested")}},
+			{Type: "numbered_list_item", NumberedListItem: &notion.NumberedListItemBlock{Text: text("Nested too")}},
+		},
+	}},
+	{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Done"), Checked: true, Children: []notion.Block{
+		{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("Details")}},
+	}}},
+	{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Not done")}},
+	{Type: "toggle", Toggle: &notion.ToggleBlock{Text: text("More"), Children: []notion.Block{
+		{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("Hidden")}},
+	}}},
+	{Type: "quote", Quote: &notion.QuoteBlock{Text: text("Line one\nLine two")}},
+	{Type: "callout", Callout: &notion.CalloutBlock{Text: text("Note"), Icon: &notion.Icon{Type: "emoji", Emoji: "💡"}}},
+	{Type: "code", Code: &notion.CodeBlock{Text: text("fmt.Println(\"```\")"), Language: "go"}},
+	{Type: "divider", Divider: &notion.DividerBlock{}},
+	{Type: "equation", Equation: &notion.Equation{Expression: "e=mc^2"}},
+	{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: []notion.RichText{
+		{Type: "equation", Equation: &notion.Equation{Expression: "x^2"}},
+		{Type: "text", Text: &notion.Text{Content: " by "}},
+		{Type: "mention", PlainText: "@Ada", Mention: &notion.Mention{Type: "user", UserMention: &notion.User{ID: "u1"}}},
+		{Type: "text", Text: &notion.Text{Content: " in "}},
+		{Type: "mention", PlainText: "Plans", Mention: &notion.Mention{Type: "page",
+			PageMention: &notion.PageMention{ID: "a1b2-c3"}}},
+	}}},
+	{Type: "image", Image: &notion.FileBlock{Type: "external", External: &notion.ExternalFile{URL: "https://example.com/a b.png"},
+		Caption: text("A cat")}},
+	{Type: "pdf", PDF: &notion.FileBlock{Type: "file", File: &notion.HostedFile{URL: "https://s3.example.com/doc.pdf?sig=1"}}},
+	{Type: "table", Table: &notion.TableBlock{TableWidth: 2, Children: []notion.Block{
+		{Type: "table_row", TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{text("a|b"), text("c")}}},
+		{Type: "table_row", TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{text("1"), text("2\n3")}}},
+	}}},
+	{ID: "d4e5", Type: "child_page", ChildPage: &notion.ChildPageBlock{Title: "Sub page"}},
+	{Type: "table_of_contents", TableOfContents: &notion.TableOfContentsBlock{}},
+	{Type: "unsupported"},
+}
+
+const testMarkdown = "# Title\n\n" +
+	"Some **bold text**, `code`, *~~gone~~* and a [link](https://example.com) to 2\\*3\\_\\[x\\].\n\n" +
+	"- One\n" +
+	"  1. Nested\n" +
+	"  2. Nested too\n" +
+	"- [x] Done\n\n" +
+	"  Details\n" +
+	"- [ ] Not done\n\n" +
+	"<details>\n<summary>More</summary>\n\nHidden\n\n</details>\n\n" +
+	"> Line one\\\n> Line two\n\n" +
+	"> 💡 Note\n\n" +
+	"````go\nfmt.Println(\"```\")\n````\n\n" +
+	"---\n\n" +
+	"$$\ne=mc^2\n$$\n\n" +
+	"$x^2$ by @Ada in [Plans](https://www.notion.so/a1b2c3)\n\n" +
+	"![A cat](<https://example.com/a b.png>)\n\n" +
+	"[doc.pdf](https://s3.example.com/doc.pdf?sig=1)\n\n" +
+	"| a\\|b | c |\n| --- | --- |\n| 1 | 2<br>3 |\n\n" +
+	"[Sub page](https://www.notion.so/d4e5)\n"
+
+func TestRender(t *testing.T) {
+	assert.Equal(t, testMarkdown, markdown.Render(testBlocks))
+	assert.Equal(t, "", markdown.Render(nil))
+}
+
+func TestRenderer_Hooks(t *testing.T) {
+	r := &markdown.Renderer{
+		ChildPage: func(block notion.Block) string {
+			return "[[" + block.ChildPage.Title + "]]"
+		},
+		Unsupported: func(block notion.Block) string {
+			return "<!-- " + block.Type + " -->"
+		},
+		Mention: func(mention notion.RichText) string {
+			return "@" + mention.Mention.Type
+		},
+	}
+
+	out := r.Render(testBlocks[len(testBlocks)-3:])
+	assert.Equal(t, "[[Sub page]]\n\n<!-- table_of_contents -->\n\n<!-- unsupported -->\n", out)
+	assert.Equal(t, "$x^2$ by @user in @page", r.RenderRichText(testBlocks[11].Paragraph.Text))
+}
+
+func TestRenderRichText(t *testing.T) {
+	cases := []struct {
+		expected string
+		richText []notion.RichText
+	}{
+		{"plain", []notion.RichText{{PlainText: "plain"}}},
+		{"\\# not a heading", text("# not a heading")},
+		{"\\- not a list", text("- not a list")},
+		{"12\\) not a list", text("12) not a list")},
+		{"a\\\n\\+ b", text("a\n+ b")},
+		{"-5 and 1.5", text("-5 and 1.5")},
+		{"``a`b``", []notion.RichText{styled("a`b", notion.Annotations{Code: true})}},
+		{" *spaced* ", []notion.RichText{styled(" spaced ", notion.Annotations{Italic: true})}},
+		{"[site](https://example.com)", []notion.RichText{{PlainText: "site", Href: "https://example.com"}}},
+		{"trailing", text("trailing\n")},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, markdown.RenderRichText(c.richText))
+	}
+}
+
+func TestRender_RoundTripParagraphs(t *testing.T) {
+	for _, content := range []string{
+		"# not a heading", "- not a list", "+ not a list", "* not a list", "1. not a list", "12) not a list",
+		"> not a quote", "---", "first\n- second\n3. third", "-5 and 1.5 stay as they are",
+	} {
+		paragraph := notion.Block{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text(content)}}
+
+		blocks := markdown.Parse(markdown.Render([]notion.Block{paragraph}))
+		if assert.Len(t, blocks, 1, content) && assert.Equal(t, "paragraph", blocks[0].Type, content) {
+			assert.Equal(t, content, notion.PlainText(blocks[0].Paragraph.Text), content)
+		}
+	}
+}
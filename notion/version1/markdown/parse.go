@@ -0,0 +1,543 @@
+package markdown
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxChildren is the most blocks Notion accepts in one children list.
+const maxChildren = 100
+
+var (
+	headingLine = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fenceLine   = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	breakLine   = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	itemLine    = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?:[ \t]+|$)`)
+	quoteLine   = regexp.MustCompile(`^ {0,3}> ?`)
+	imageLine   = regexp.MustCompile(`^ {0,3}!\[([^\]]*)\]\((?:<([^>]*)>|([^)\s]+))(?:\s+"[^"]*")?\)[ \t]*$`)
+	taskMarker  = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+)
+
+// codeLanguages maps common names of languages in Markdown code fences to Notion's.
+var codeLanguages = map[string]string{
+	"":       "plain text",
+	"text":   "plain text",
+	"golang": "go",
+	"js":     "javascript",
+	"ts":     "typescript",
+	"py":     "python",
+	"rb":     "ruby",
+	"sh":     "shell",
+	"yml":    "yaml",
+	"md":     "markdown",
+	"cs":     "c#",
+	"csharp": "c#",
+	"cpp":    "c++",
+}
+
+// Parse converts Markdown into blocks for CreatePageBodyParams.Children or AppendBlockChildrenBodyParams.
+// It handles headings (levels past 3 become heading_3), paragraphs, bulleted, numbered and task lists with nesting,
+// quotes, fenced code, $$ equations, thematic breaks and images on their own line. Inline, it handles bold,
+// italic, strikethrough, code spans, links and autolinks.
+// Text longer than Notion's limit of 2000 characters is split over several rich text objects. Use Append to append
+// the blocks within Notion's limits on the number and nesting of children.
+func Parse(src string) []notion.Block {
+	src = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(src)
+	return parseBlocks(strings.Split(src, "\n"))
+}
+
+// Batches splits blocks into batches of at most 100, the most Notion accepts in one request. It leaves the children of
+// blocks as they are, so they may still be too many or nested too deep; Append handles those too.
+func Batches(blocks []notion.Block) [][]notion.Block {
+	var batches [][]notion.Block
+	for len(blocks) > maxChildren {
+		batches = append(batches, blocks[:maxChildren])
+		blocks = blocks[maxChildren:]
+	}
+	if len(blocks) > 0 {
+		batches = append(batches, blocks)
+	}
+	return batches
+}
+
+func parseBlocks(lines []string) []notion.Block {
+	var blocks []notion.Block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fenceLine.MatchString(line):
+			var block notion.Block
+			block, i = parseCode(lines, i)
+			blocks = append(blocks, block)
+		case strings.TrimSpace(line) == "$$":
+			var block notion.Block
+			block, i = parseEquation(lines, i)
+			blocks = append(blocks, block)
+		case headingLine.MatchString(line):
+			blocks = append(blocks, heading(headingLine.FindStringSubmatch(line)))
+			i++
+		case breakLine.MatchString(line):
+			blocks = append(blocks, notion.Block{Object: "block", Type: "divider", Divider: &notion.DividerBlock{}})
+			i++
+		case quoteLine.MatchString(line):
+			var block notion.Block
+			block, i = parseQuote(lines, i)
+			blocks = append(blocks, block)
+		case itemLine.MatchString(line):
+			var block notion.Block
+			block, i = parseItem(lines, i)
+			blocks = append(blocks, block)
+		case imageLine.MatchString(line):
+			match := imageLine.FindStringSubmatch(line)
+			blocks = append(blocks, notion.Block{Object: "block", Type: "image", Image: &notion.FileBlock{
+				Type:     "external",
+				External: &notion.ExternalFile{URL: match[2] + match[3]},
+				Caption:  parseInline(match[1]),
+			}})
+			i++
+		default:
+			var block notion.Block
+			block, i = parseParagraph(lines, i)
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether line starts a block that interrupts a paragraph.
+func startsBlock(line string) bool {
+	return fenceLine.MatchString(line) || headingLine.MatchString(line) || breakLine.MatchString(line) ||
+		quoteLine.MatchString(line) || itemLine.MatchString(line) || strings.TrimSpace(line) == "$$"
+}
+
+func heading(match []string) notion.Block {
+	text := parseInline(match[2])
+	switch len(match[1]) {
+	case 1:
+		return notion.Block{Object: "block", Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{Text: text}}
+	case 2:
+		return notion.Block{Object: "block", Type: "heading_2", HeadingTwo: &notion.HeadingTwoBlock{Text: text}}
+	}
+	return notion.Block{Object: "block", Type: "heading_3", HeadingThree: &notion.HeadingThreeBlock{Text: text}}
+}
+
+func parseParagraph(lines []string, i int) (notion.Block, int) {
+	var b strings.Builder
+	for start := i; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || (i > start && startsBlock(line)) {
+			break
+		}
+		if i > start {
+			previous := lines[i-1]
+			if strings.HasSuffix(previous, "  ") || strings.HasSuffix(previous, `\`) {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		line = strings.TrimSpace(line)
+		b.WriteString(strings.TrimSuffix(line, `\`))
+	}
+
+	return notion.Block{
+		Object:    "block",
+		Type:      "paragraph",
+		Paragraph: &notion.ParagraphBlock{Text: parseInline(b.String())},
+	}, i
+}
+
+func parseCode(lines []string, i int) (notion.Block, int) {
+	match := fenceLine.FindStringSubmatch(lines[i])
+	fence, language := match[1], strings.ToLower(match[2])
+	if name, ok := codeLanguages[language]; ok {
+		language = name
+	}
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	return notion.Block{Object: "block", Type: "code", Code: &notion.CodeBlock{
+		Text:     notion.SplitText(notion.RichText{Type: "text", Text: &notion.Text{Content: strings.Join(code, "\n")}}),
+		Language: language,
+	}}, i
+}
+
+func parseEquation(lines []string, i int) (notion.Block, int) {
+	var expression []string
+	for i++; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "$$" {
+			i++
+			break
+		}
+		expression = append(expression, lines[i])
+	}
+
+	return notion.Block{Object: "block", Type: "equation", Equation: &notion.Equation{
+		Expression: strings.Join(expression, "\n"),
+	}}, i
+}
+
+// parseQuote parses a quote, whose first paragraph is its text and other blocks its children.
+func parseQuote(lines []string, i int) (notion.Block, int) {
+	var inner []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if loc := quoteLine.FindStringIndex(line); loc != nil {
+			inner = append(inner, line[loc[1]:])
+			continue
+		}
+		// Lazy continuation lines carry on the quoted paragraph.
+		if strings.TrimSpace(line) == "" || startsBlock(line) || strings.TrimSpace(inner[len(inner)-1]) == "" {
+			break
+		}
+		inner = append(inner, line)
+	}
+
+	text, children := splitFirstParagraph(parseBlocks(inner))
+	return notion.Block{Object: "block", Type: "quote", Quote: &notion.QuoteBlock{Text: text, Children: children}}, i
+}
+
+// parseItem parses a list item, whose first paragraph is its text and other blocks, like nested lists, its
+// children.
+func parseItem(lines []string, i int) (notion.Block, int) {
+	match := itemLine.FindStringSubmatch(lines[i])
+	indent := len(match[0])
+	if strings.TrimSpace(lines[i]) == match[2] {
+		indent = len(match[1]) + len(match[2]) + 1
+	}
+
+	inner := []string{lines[i][len(match[0]):]}
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			inner = append(inner, "")
+			continue
+		case leadingSpaces(line) >= indent:
+			inner = append(inner, line[indent:])
+			continue
+		case strings.TrimSpace(inner[len(inner)-1]) != "" && !startsBlock(line):
+			// A lazy continuation line of the item's paragraph.
+			inner = append(inner, line)
+			continue
+		}
+		break
+	}
+
+	block := notion.Block{Object: "block"}
+	checked, task := false, taskMarker.FindStringSubmatch(inner[0])
+	if task != nil {
+		checked = task[1] != " "
+		inner[0] = inner[0][len(task[0]):]
+	}
+
+	text, children := splitFirstParagraph(parseBlocks(inner))
+	switch {
+	case task != nil:
+		block.Type, block.ToDo = "to_do", &notion.ToDoBlock{Text: text, Checked: checked, Children: children}
+	case strings.ContainsAny(match[2], ".)"):
+		block.Type = "numbered_list_item"
+		block.NumberedListItem = &notion.NumberedListItemBlock{Text: text, Children: children}
+	default:
+		block.Type = "bulleted_list_item"
+		block.BulletedListItem = &notion.BulletedListItemBlock{Text: text, Children: children}
+	}
+	return block, i
+}
+
+// splitFirstParagraph returns the text of blocks' first block if it is a paragraph, and the other blocks.
+func splitFirstParagraph(blocks []notion.Block) ([]notion.RichText, []notion.Block) {
+	if len(blocks) > 0 && blocks[0].Paragraph != nil {
+		text, children := blocks[0].Paragraph.Text, blocks[1:]
+		if len(children) == 0 {
+			children = nil
+		}
+		return text, children
+	}
+	return nil, blocks
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// parseInline parses inline Markdown into rich text.
+func parseInline(s string) []notion.RichText {
+	var p inlineParser
+	p.parse(s, notion.Annotations{}, "")
+
+	var richText []notion.RichText
+	for _, rt := range p.richText {
+		richText = append(richText, notion.SplitText(rt)...)
+	}
+	return richText
+}
+
+type inlineParser struct {
+	richText []notion.RichText
+}
+
+// add adds content with annotations a, linked to url, joining it to the previous text if it is styled the same.
+func (p *inlineParser) add(content string, a notion.Annotations, url string) {
+	if content == "" {
+		return
+	}
+	if n := len(p.richText); n > 0 {
+		last := &p.richText[n-1]
+		if annotations(*last) == a && textURL(*last) == url {
+			last.Text.Content += content
+			return
+		}
+	}
+
+	rt := notion.RichText{Type: "text", Text: &notion.Text{Content: content}}
+	if url != "" {
+		rt.Text.Link = &notion.Link{URL: url}
+	}
+	if a != (notion.Annotations{}) {
+		rt.Annotations = &a
+	}
+	p.richText = append(p.richText, rt)
+}
+
+// parse parses s, whose text has annotations a and links to url unless its own formatting says otherwise.
+// Delimiters without a match are kept as text.
+func (p *inlineParser) parse(s string, a notion.Annotations, url string) {
+	var plain strings.Builder
+	flush := func() {
+		p.add(plain.String(), a, url)
+		plain.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			plain.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := runLength(s, i)
+			if end := closingRun(s, i+n, s[i:i+n]); end >= 0 {
+				flush()
+				code := s[i+n : end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				ca := a
+				ca.Code = true
+				p.add(code, ca, url)
+				i = end + n
+				continue
+			}
+			plain.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case c == '*' || c == '_' || c == '~':
+			n := runLength(s, i)
+			d := n
+			if d > 3 {
+				d = 3
+			}
+			if c == '~' && n != 2 || !canOpen(s, i, d) {
+				plain.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			if end := closingDelimiter(s, i+d, s[i:i+d]); end >= 0 {
+				flush()
+				ea := a
+				switch {
+				case c == '~':
+					ea.Strikethrough = true
+				case d == 1:
+					ea.Italic = true
+				case d == 2:
+					ea.Bold = true
+				default:
+					ea.Bold, ea.Italic = true, true
+				}
+				p.parse(s[i+d:end], ea, url)
+				i = end + d
+				continue
+			}
+			plain.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case c == '[' || c == '!' && strings.HasPrefix(s[i+1:], "["):
+			// Images inside text become links to the image.
+			start := i
+			if c == '!' {
+				start++
+			}
+			if label, dest, end, ok := parseLink(s, start); ok {
+				flush()
+				p.parse(label, a, dest)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				target := s[i+1 : i+end]
+				if isAutolink(target) {
+					flush()
+					p.add(target, a, target)
+					i += end + 1
+					continue
+				}
+			}
+		}
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// canOpen reports whether the delimiter of length n at s[i] can open emphasis: it must be followed by
+// non-whitespace, and underscores can't open emphasis inside a word.
+func canOpen(s string, i, n int) bool {
+	if i+n >= len(s) || isSpace(s[i+n]) {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isWordChar(s[i-1])
+}
+
+// closingDelimiter returns the index of the run of exactly delimiter in s at or after from that closes emphasis,
+// or -1.
+func closingDelimiter(s string, from int, delimiter string) int {
+	for i := from; i < len(s); {
+		switch {
+		case s[i] == '\\':
+			i += 2
+			continue
+		case s[i] == '`':
+			// Delimiters inside code spans don't count.
+			n := runLength(s, i)
+			if end := closingRun(s, i+n, s[i:i+n]); end >= 0 {
+				i = end + n
+			} else {
+				i += n
+			}
+			continue
+		case s[i] != delimiter[0]:
+			i++
+			continue
+		}
+
+		n := runLength(s, i)
+		after := i + n
+		if n == len(delimiter) && !isSpace(s[i-1]) &&
+			(delimiter[0] != '_' || after == len(s) || !isWordChar(s[after])) {
+			return i
+		}
+		i = after
+	}
+	return -1
+}
+
+// closingRun returns the index of the run of exactly run in s at or after from, or -1.
+func closingRun(s string, from int, run string) int {
+	for i := from; i < len(s); {
+		j := strings.Index(s[i:], run)
+		if j < 0 {
+			return -1
+		}
+		i += j
+		n := runLength(s, i)
+		if n == len(run) {
+			return i
+		}
+		i += n
+	}
+	return -1
+}
+
+// parseLink parses a link [label](destination "title") at s[i], returning the index after it.
+func parseLink(s string, i int) (label, dest string, end int, ok bool) {
+	depth, close := 0, -1
+	for j := i; j < len(s) && close < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				close = j
+			}
+		}
+	}
+	if close < 0 || !strings.HasPrefix(s[close+1:], "(") {
+		return "", "", 0, false
+	}
+
+	rest := s[close+2:]
+	if strings.HasPrefix(rest, "<") {
+		n := strings.IndexByte(rest, '>')
+		if n < 0 {
+			return "", "", 0, false
+		}
+		dest, rest = rest[1:n], rest[n+1:]
+	} else {
+		n := strings.IndexAny(rest, " \t)")
+		if n < 0 {
+			return "", "", 0, false
+		}
+		dest, rest = rest[:n], rest[n:]
+	}
+	n := strings.IndexByte(rest, ')')
+	if n < 0 || dest == "" {
+		return "", "", 0, false
+	}
+	end = len(s) - len(rest) + n + 1
+	return s[i+1 : close], dest, end, true
+}
+
+func isAutolink(s string) bool {
+	return !strings.ContainsAny(s, " \t<") &&
+		(strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "mailto:"))
+}
+
+func annotations(rt notion.RichText) notion.Annotations {
+	if rt.Annotations == nil {
+		return notion.Annotations{}
+	}
+	return *rt.Annotations
+}
+
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isWordChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= utf8.RuneSelf
+}
@@ -0,0 +1,147 @@
+package markdown_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/markdown"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func block(blockType string, set func(b *notion.Block)) notion.Block {
+	b := notion.Block{Object: "block", Type: blockType}
+	set(&b)
+	return b
+}
+
+func TestParse(t *testing.T) {
+	src := "# Title\n\n" +
+		"Some **bold text**, `code`, *~~gone~~* and a [link](https://example.com)\n" +
+		"to 2\\*3.\n\n" +
+		"#### Deep\n\n" +
+		"- One\n" +
+		"  1. Nested\n" +
+		"  2. Nested too\n" +
+		"- [x] Done\n\n" +
+		"  Details\n" +
+		"- [ ] Not done\n\n" +
+		"> Line one\\\n> Line two\n\n" +
+		"```golang\nfmt.Println()\n```\n\n" +
+		"***\n\n" +
+		"$$\ne=mc^2\n$$\n\n" +
+		"![A cat](<https://example.com/a b.png>)\n"
+
+	expected := []notion.Block{
+		block("heading_1", func(b *notion.Block) { b.HeadingOne = &notion.HeadingOneBlock{Text: text("Title")} }),
+		block("paragraph", func(b *notion.Block) {
+			b.Paragraph = &notion.ParagraphBlock{Text: []notion.RichText{
+				{Type: "text", Text: &notion.Text{Content: "Some "}},
+				styled("bold text", notion.Annotations{Bold: true}),
+				{Type: "text", Text: &notion.Text{Content: ", "}},
+				styled("code", notion.Annotations{Code: true}),
+				{Type: "text", Text: &notion.Text{Content: ", "}},
+				styled("gone", notion.Annotations{Strikethrough: true, Italic: true}),
+				{Type: "text", Text: &notion.Text{Content: " and a "}},
+				{Type: "text", Text: &notion.Text{Content: "link", Link: &notion.Link{URL: "https://example.com"}}},
+				{Type: "text", Text: &notion.Text{Content: " to 2*3."}},
+			}}
+		}),
+		block("heading_3", func(b *notion.Block) { b.HeadingThree = &notion.HeadingThreeBlock{Text: text("Deep")} }),
+		block("bulleted_list_item", func(b *notion.Block) {
+			b.BulletedListItem = &notion.BulletedListItemBlock{Text: text("One"), Children: []notion.Block{
+				block("numbered_list_item", func(b *notion.Block) {
+					b.NumberedListItem = &notion.NumberedListItemBlock{Text: text("Nested")}
+				}),
+				block("numbered_list_item", func(b *notion.Block) {
+					b.NumberedListItem = &notion.NumberedListItemBlock{Text: text("Nested too")}
+				}),
+			}}
+		}),
+		block("to_do", func(b *notion.Block) {
+			b.ToDo = &notion.ToDoBlock{Text: text("Done"), Checked: true, Children: []notion.Block{
+				block("paragraph", func(b *notion.Block) { b.Paragraph = &notion.ParagraphBlock{Text: text("Details")} }),
+			}}
+		}),
+		block("to_do", func(b *notion.Block) { b.ToDo = &notion.ToDoBlock{Text: text("Not done")} }),
+		block("quote", func(b *notion.Block) { b.Quote = &notion.QuoteBlock{Text: text("Line one\nLine two")} }),
+		block("code", func(b *notion.Block) {
+			b.Code = &notion.CodeBlock{Text: text("fmt.Println()"), Language: "go"}
+		}),
+		block("divider", func(b *notion.Block) { b.Divider = &notion.DividerBlock{} }),
+		block("equation", func(b *notion.Block) { b.Equation = &notion.Equation{Expression: "e=mc^2"} }),
+		block("image", func(b *notion.Block) {
+			b.Image = &notion.FileBlock{Type: "external", External: &notion.ExternalFile{URL: "https://example.com/a b.png"},
+				Caption: text("A cat")}
+		}),
+	}
+	assert.Equal(t, expected, markdown.Parse(src))
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	src := "## Plan\n\n" +
+		"Read the **docs** and *then* ~~skip~~ `go vet`, see <https://example.com>.\n\n" +
+		"1. First\n" +
+		"2. Second\n" +
+		"   - Nested\n" +
+		"     - [ ] Deeper\n\n" +
+		"> Quoted\n"
+	assert.Equal(t, "## Plan\n\n"+
+		"Read the **docs** and *then* ~~skip~~ `go vet`, see [https://example.com](https://example.com).\n\n"+
+		"1. First\n"+
+		"2. Second\n"+
+		"   - Nested\n"+
+		"     - [ ] Deeper\n\n"+
+		"> Quoted\n", markdown.Render(markdown.Parse(src)))
+}
+
+func TestParse_Inline(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected []notion.RichText
+	}{
+		{"snake_case_name", text("snake_case_name")},
+		{"a * b * c", text("a * b * c")},
+		{"**unclosed", text("**unclosed")},
+		{"`a**b**`", []notion.RichText{styled("a**b**", notion.Annotations{Code: true})}},
+		{"***both***", []notion.RichText{styled("both", notion.Annotations{Bold: true, Italic: true})}},
+		{"__bold__ _it_", []notion.RichText{
+			styled("bold", notion.Annotations{Bold: true}),
+			{Type: "text", Text: &notion.Text{Content: " "}},
+			styled("it", notion.Annotations{Italic: true}),
+		}},
+		{"[**a** b](<https://x.y/z w>)", []notion.RichText{
+			{Type: "text", Text: &notion.Text{Content: "a", Link: &notion.Link{URL: "https://x.y/z w"}},
+				Annotations: &notion.Annotations{Bold: true}},
+			{Type: "text", Text: &notion.Text{Content: " b", Link: &notion.Link{URL: "https://x.y/z w"}}},
+		}},
+		{"[not a link] (x)", text("[not a link] (x)")},
+	}
+	for _, c := range cases {
+		blocks := markdown.Parse(c.src)
+		if assert.Len(t, blocks, 1, c.src) {
+			assert.Equal(t, c.expected, blocks[0].Paragraph.Text, c.src)
+		}
+	}
+}
+
+func TestParse_LongText(t *testing.T) {
+	blocks := markdown.Parse("**" + strings.Repeat("é", 4500) + "**")
+
+	richText := blocks[0].Paragraph.Text
+	if assert.Len(t, richText, 3) {
+		assert.Equal(t, strings.Repeat("é", 2000), richText[0].Text.Content)
+		assert.Equal(t, strings.Repeat("é", 500), richText[2].Text.Content)
+		assert.True(t, richText[2].Annotations.Bold)
+	}
+}
+
+func TestBatches(t *testing.T) {
+	blocks := markdown.Parse(strings.Repeat("- item\n", 250))
+
+	batches := markdown.Batches(blocks)
+	if assert.Len(t, batches, 3) {
+		assert.Len(t, batches[0], 100)
+		assert.Len(t, batches[2], 50)
+	}
+	assert.Nil(t, markdown.Batches(nil))
+}
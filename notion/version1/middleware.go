@@ -0,0 +1,83 @@
+package version1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Doer sends a request to the Notion API and returns its response. *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a function used as a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending the requests of every service, e.g. to trace calls, log request IDs or set
+// headers per call. next sends a call once, retries and rate limiting included, so a Middleware sees each call
+// exactly once.
+//
+// The context of a request tells which call it is, see OperationName and CallInfo. Its body can be read with
+// req.GetBody without consuming it, and ResponseError decodes the error of a failed response.
+type Middleware func(next Doer) Doer
+
+// Call describes a call to the Notion API. Attempts and RateLimitWait are set once next.Do returns to the Middleware.
+type Call struct {
+	// Operation names the call, as returned by OperationName.
+	Operation string
+
+	// ObjectID is the ID of the database, page, block or user the call is about, if any.
+	ObjectID string
+
+	// Page is the number of the page of results an iterator is fetching, starting at 1, or 0 outside iterators.
+	Page int
+
+	// Attempts is how many times the request was sent, retries included.
+	Attempts int
+
+	// RateLimitWait is how long the Client's RateLimiter delayed the call, over all its attempts.
+	RateLimitWait time.Duration
+}
+
+type callKey struct{}
+
+// CallInfo returns the Call whose request has ctx, or nil for a context that doesn't belong to such a request.
+func CallInfo(ctx context.Context) *Call {
+	call, _ := ctx.Value(callKey{}).(*Call)
+	return call
+}
+
+// OperationName returns the name of the call to the Notion API whose request has ctx, e.g. "databases.query",
+// "pages.create" or "blocks.children.append". It returns "" for a context that doesn't belong to such a request.
+func OperationName(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(operation)
+	return op.name
+}
+
+// ResponseError returns the error Notion answered with, as the APIError the service returns, or nil if resp
+// succeeded. It leaves the body of resp for the service to decode.
+func ResponseError(resp *http.Response) error {
+	if resp == nil || isSuccess(resp.StatusCode) {
+		return nil
+	}
+
+	apiError := APIError{Message: http.StatusText(resp.StatusCode)}
+	if resp.Body != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			json.Unmarshal(body, &apiError)
+		}
+	}
+	return relevantError(context.Background(), resp, nil, apiError)
+}
@@ -0,0 +1,163 @@
+package version1_test
+
+import (
+	"context"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/databases/123/query", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "databases.query", r.Header.Get("X-Operation"))
+		assertPostJSON(t, testSingleFilterParamsJSON, r)
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprintf(w, testRateLimitedJSON)
+			return
+		}
+		fmt.Fprintf(w, testFilterResJSON)
+	})
+
+	var seen []string
+	record := func(name string) notion.Middleware {
+		return func(next notion.Doer) notion.Doer {
+			return notion.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				seen = append(seen, name+" "+notion.OperationName(req.Context()))
+				resp, err := next.Do(req)
+				seen = append(seen, fmt.Sprintf("%v %v", name, resp.StatusCode))
+				return resp, err
+			})
+		}
+	}
+	setOperation := func(next notion.Doer) notion.Doer {
+		return notion.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := req.GetBody()
+			assert.Nil(t, err)
+			data, _ := ioutil.ReadAll(body)
+			assert.Equal(t, testSingleFilterParamsJSON, string(data))
+
+			req.Header.Set("X-Operation", notion.OperationName(req.Context()))
+			return next.Do(req)
+		})
+	}
+
+	client := notion.NewClient(httpClient, "0000", notion.WithRetryPolicy(testRetryPolicy),
+		notion.WithMiddleware(record("outer"), setOperation), notion.WithMiddleware(record("inner")))
+	resp, _, err := client.Databases.QueryDatabase(context.Background(), "123", testSingleFilterParams)
+	assert.Nil(t, err)
+	assert.Equal(t, testFilterRes, resp)
+	assert.Equal(t, 2, calls)
+	// Retries happen below the middleware, which sees the call once.
+	assert.Equal(t, []string{"outer databases.query", "inner databases.query", "inner 200", "outer 200"}, seen)
+}
+
+func TestClient_Middleware_ResponseError(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/pages/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Notion-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"object":"error","status":404,"code":"object_not_found","message":"Not found."}`)
+	})
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUserJSON)
+	})
+
+	var apiErrors []error
+	client := notion.NewClient(httpClient, "0000", notion.WithMiddleware(func(next notion.Doer) notion.Doer {
+		return notion.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			apiErrors = append(apiErrors, notion.ResponseError(resp))
+			return resp, err
+		})
+	}))
+
+	_, _, err := client.Pages.RetrievePage(context.Background(), "123")
+	expected := notion.APIError{
+		Object:    "error",
+		Status:    404,
+		Code:      "object_not_found",
+		Message:   "Not found.",
+		RequestID: "req-1",
+	}
+	// The service still decodes the error body.
+	assert.Equal(t, expected, err)
+
+	_, _, err = client.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []error{expected, nil}, apiErrors)
+	assert.Equal(t, "", notion.OperationName(context.Background()))
+}
+
+func TestClient_Middleware_CallInfo(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/users/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		switch calls {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprintf(w, testRateLimitedJSON)
+		case 2:
+			fmt.Fprintf(w, `{"object":"list","results":[{"object":"user","id":"1"}],"next_cursor":"2","has_more":true}`)
+		default:
+			fmt.Fprintf(w, `{"object":"list","results":[{"object":"user","id":"2"}],"has_more":false}`)
+		}
+	})
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUserJSON)
+	})
+
+	var seen []notion.Call
+	client := notion.NewClient(httpClient, "0000", notion.WithRetryPolicy(testRetryPolicy),
+		notion.WithRateLimiter(notion.NewRateLimiter(50, 1)),
+		notion.WithMiddleware(func(next notion.Doer) notion.Doer {
+			return notion.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				resp, err := next.Do(req)
+				seen = append(seen, *notion.CallInfo(req.Context()))
+				return resp, err
+			})
+		}))
+
+	it := client.Users.ListIter(context.Background(), nil)
+	for it.Next() {
+	}
+	assert.Nil(t, it.Err())
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+
+	if assert.Len(t, seen, 3) {
+		assert.Equal(t, "users.list", seen[0].Operation)
+		assert.Equal(t, 1, seen[0].Page)
+		assert.Equal(t, 2, seen[0].Attempts)
+		// The retry backs off for less than the limiter's interval, so it waits for the limiter.
+		assert.True(t, seen[0].RateLimitWait > 0)
+
+		assert.Equal(t, 2, seen[1].Page)
+		assert.Equal(t, 1, seen[1].Attempts)
+
+		seen[2].RateLimitWait = 0
+		assert.Equal(t, notion.Call{Operation: "users.retrieve", ObjectID: "123", Attempts: 1}, seen[2])
+	}
+	assert.Nil(t, notion.CallInfo(context.Background()))
+}
@@ -1,7 +1,10 @@
 package version1
 
 import (
+	"context"
 	"github.com/dghubble/sling"
+	"io"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -11,6 +14,14 @@ const notionVersion = "2021-05-13"
 type Client struct {
 	sling *sling.Sling
 
+	// RetryPolicy, if set, makes every service retry rate limited and temporarily failed requests.
+	// Retries are disabled by default. Set it before the Client is used.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter, if set, delays requests made by every service so they stay within its rate.
+	// It can be shared by several Clients. Set it before the Client is used.
+	RateLimiter *RateLimiter
+
 	// Notion API Services
 	Users     *UserService
 	Databases *DatabaseService
@@ -20,25 +31,162 @@ type Client struct {
 	Auth      *AuthService
 }
 
-func AuthClient(client *http.Client) *Client {
-	base := sling.New().Client(client).Base(notionAPI)
+// AuthClient returns a Client whose Auth service can be used to complete the OAuth flow of a public integration.
+func AuthClient(client *http.Client, opts ...Option) *Client {
+	c, base := newClient(client, opts)
+
+	c.Auth = newAuthService(base.New())
+	return c
+}
+
+// NewClient returns a Client that authenticates its requests with accessToken.
+func NewClient(client *http.Client, accessToken string, opts ...Option) *Client {
+	c, base := newClient(client, opts)
+	base.Set("Authorization", "Bearer "+accessToken)
+
+	c.sling = base
+	c.Users = newUserService(base.New())
+	c.Databases = newDatabaseService(base.New())
+	c.Pages = newPageService(base.New())
+	c.Blocks = newBlockService(base.New())
+	c.Search = newSearchService(base.New())
+	return c
+}
+
+// newClient returns a Client configured with opts, along with the base sling its services are built from.
+func newClient(client *http.Client, opts []Option) (*Client, *sling.Sling) {
+	o := newOptions(opts)
+	c := &Client{
+		RetryPolicy: o.retryPolicy,
+		RateLimiter: o.rateLimiter,
+	}
+
+	var doer Doer = &clientDoer{client: c, httpClient: client}
+	// The first middleware is the outermost, so it sees requests first and responses last.
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		doer = o.middleware[i](doer)
+	}
 
-	return &Client{
-		Auth: newAuthService(base.New()),
+	base := sling.New().Doer(doer).Base(o.baseURL)
+	base.Set("Notion-Version", o.version)
+	if o.userAgent != "" {
+		base.Set("User-Agent", o.userAgent)
 	}
+	for key, values := range o.header {
+		for _, value := range values {
+			base.Add(key, value)
+		}
+	}
+
+	return c, base
+}
+
+// operation describes a single call to the Notion API.
+type operation struct {
+	// name identifies the call to Middleware, e.g. "databases.query".
+	name string
+
+	// objectID is the ID of the database, page, block or user the call is about, if any.
+	objectID string
+
+	// idempotent reports whether repeating the call has the same effect as making it once.
+	idempotent bool
+}
+
+var (
+	idempotent    = operation{idempotent: true}
+	nonIdempotent = operation{idempotent: false}
+)
+
+// named returns op with its name set.
+func (op operation) named(name string) operation {
+	op.name = name
+	return op
 }
 
-func NewClient(client *http.Client, accessToken string) *Client {
-	base := sling.New().Client(client).Base(notionAPI)
-	base.Add("Authorization", "Bearer "+accessToken)
-	base.Add("Notion-Version", notionVersion)
+// about returns op with the ID of the object it is about set.
+func (op operation) about(objectID string) operation {
+	op.objectID = objectID
+	return op
+}
+
+type operationKey struct{}
+
+// receive sends the request built by s with ctx attached, so cancelling ctx aborts the call.
+// Successful responses are decoded into successV and failed ones are returned as an error.
+func receive(ctx context.Context, op operation, s *sling.Sling, successV interface{}) (*http.Response, error) {
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
+	}
+
+	call := &Call{Operation: op.name, ObjectID: op.objectID, Page: iteratorPage(ctx)}
+	reqCtx := context.WithValue(context.WithValue(ctx, operationKey{}, op), callKey{}, call)
+
+	apiError := new(APIError)
+	resp, err := s.Do(req.WithContext(reqCtx), successV, apiError)
+
+	return resp, relevantError(ctx, resp, err, *apiError)
+}
+
+// clientDoer sends the requests of every service built by a Client through httpClient,
+// applying the Client's RateLimiter and RetryPolicy.
+type clientDoer struct {
+	client     *Client
+	httpClient *http.Client
+}
+
+func (d *clientDoer) Do(req *http.Request) (*http.Response, error) {
+	httpClient := d.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	policy := d.client.RetryPolicy
+	op, _ := req.Context().Value(operationKey{}).(operation)
+	call := CallInfo(req.Context())
+	if call == nil {
+		call = new(Call)
+	}
+
+	for attempt := 1; ; attempt++ {
+		// Every attempt counts against the rate limit, retries included.
+		delay, err := d.client.RateLimiter.wait(req.Context(), req.Header.Get("Authorization"))
+		if err != nil {
+			return nil, err
+		}
+		call.RateLimitWait += delay
+		call.Attempts = attempt
+
+		resp, err := httpClient.Do(req)
+		if err != nil || policy == nil || attempt >= policy.MaxAttempts ||
+			!policy.retryable(resp.StatusCode, op.idempotent) {
+			return resp, err
+		}
+
+		// The body has already been sent, so the request can only be repeated if it can be rebuilt.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := policy.backoff(attempt, resp)
+		if !ok {
+			return resp, nil
+		}
+
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
 
-	return &Client{
-		sling:     base,
-		Users:     newUserService(base.New()),
-		Databases: newDatabaseService(base.New()),
-		Pages:     newPageService(base.New()),
-		Blocks:    newBlockService(base.New()),
-		Search:    newSearchService(base.New()),
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
 	}
 }
@@ -1,12 +1,16 @@
 package version1_test
 
 import (
+	"context"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 // testServer returns an http Client, ServeMux, and Server. The client proxies
@@ -50,3 +54,32 @@ func assertPostJSON(t *testing.T, expected string, req *http.Request) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, string(data))
 }
+
+func TestClient_ContextDeadline(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Users.RetrieveUser(ctx, "123")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.False(t, errors.As(err, &notion.APIError{}))
+}
+
+func TestClient_ContextCancelled(t *testing.T) {
+	httpClient, _, server := testServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Databases.QueryDatabase(ctx, "123", &notion.QueryDatabaseBodyParams{})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
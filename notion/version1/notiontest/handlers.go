@@ -0,0 +1,407 @@
+package notiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// list is a page of results of a paginated endpoint.
+type list struct {
+	Object     string      `json:"object"`
+	Results    interface{} `json:"results"`
+	NextCursor *string     `json:"next_cursor"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// paginate returns the bounds of the page of ids starting at the ID cursor, and the cursor of the next page.
+// Like Notion's, cursors are the IDs of the first result of a page.
+func paginate(w http.ResponseWriter, ids []string, cursor string, pageSize int) (start, end int, next *string,
+	ok bool) {
+
+	if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+	if pageSize < 0 || pageSize > maxPageSize {
+		writeError(w, http.StatusBadRequest, "", fmt.Sprintf("page_size should be between 1 and %d.", maxPageSize))
+		return 0, 0, nil, false
+	}
+
+	if cursor != "" {
+		start = -1
+		for i, id := range ids {
+			if normalizeID(id) == normalizeID(cursor) {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			writeError(w, http.StatusBadRequest, "", "start_cursor provided is invalid: "+cursor)
+			return 0, 0, nil, false
+		}
+	}
+
+	end = start + pageSize
+	if end >= len(ids) {
+		return start, len(ids), nil, true
+	}
+	return start, end, &ids[end], true
+}
+
+// queryPageSize reads the page_size query parameter.
+func queryPageSize(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("page_size")
+	if value == "" {
+		return 0, true
+	}
+	pageSize, err := strconv.Atoi(value)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "", "page_size should be a number.")
+		return 0, false
+	}
+	return pageSize, true
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	pageSize, ok := queryPageSize(w, r)
+	if !ok {
+		return
+	}
+	ids := make([]string, len(s.users))
+	for i, user := range s.users {
+		ids[i] = user.ID
+	}
+	start, end, next, ok := paginate(w, ids, r.URL.Query().Get("start_cursor"), pageSize)
+	if !ok {
+		return
+	}
+	results := append([]notion.User{}, s.users[start:end]...)
+	writeJSON(w, http.StatusOK, list{Object: "list", Results: results, NextCursor: next, HasMore: next != nil})
+}
+
+func (s *Server) retrieveUser(w http.ResponseWriter, id string) {
+	for _, user := range s.users {
+		if normalizeID(user.ID) == normalizeID(id) {
+			writeJSON(w, http.StatusOK, user)
+			return
+		}
+	}
+	notFound(w, "user", id)
+}
+
+func (s *Server) listDatabases(w http.ResponseWriter, r *http.Request) {
+	pageSize, ok := queryPageSize(w, r)
+	if !ok {
+		return
+	}
+	ids := make([]string, len(s.databases))
+	for i, db := range s.databases {
+		ids[i] = db.ID
+	}
+	start, end, next, ok := paginate(w, ids, r.URL.Query().Get("start_cursor"), pageSize)
+	if !ok {
+		return
+	}
+	results := append([]*notion.Database{}, s.databases[start:end]...)
+	writeJSON(w, http.StatusOK, list{Object: "list", Results: results, NextCursor: next, HasMore: next != nil})
+}
+
+func (s *Server) retrieveDatabase(w http.ResponseWriter, id string) {
+	db := s.database(id)
+	if db == nil {
+		notFound(w, "database", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, db)
+}
+
+func (s *Server) queryDatabase(w http.ResponseWriter, r *http.Request, id string) {
+	db := s.database(id)
+	if db == nil {
+		notFound(w, "database", id)
+		return
+	}
+
+	var body struct {
+		Filter      json.RawMessage       `json:"filter"`
+		Sorts       []notion.SortCriteria `json:"sorts"`
+		StartCursor string                `json:"start_cursor"`
+		PageSize    int                   `json:"page_size"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	params := &notion.QueryDatabaseBodyParams{Sorts: body.Sorts}
+	if len(body.Filter) > 0 && string(body.Filter) != "null" {
+		params.Filter = body.Filter
+	}
+	if err := notion.ValidateQuery(db, params); err != nil {
+		writeError(w, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	var pages []*notion.Page
+	for _, page := range s.pages {
+		if parent, ok := page.Parent.DatabaseID(); !ok || page.Archived || normalizeID(parent) != normalizeID(db.ID) {
+			continue
+		}
+		if params.Filter != nil && !s.matches(body.Filter, page) {
+			continue
+		}
+		pages = append(pages, page)
+	}
+	sortPages(pages, db, body.Sorts)
+
+	ids := make([]string, len(pages))
+	for i, page := range pages {
+		ids[i] = page.ID
+	}
+	start, end, next, ok := paginate(w, ids, body.StartCursor, body.PageSize)
+	if !ok {
+		return
+	}
+	results := make([]notion.Page, 0, end-start)
+	for _, page := range pages[start:end] {
+		results = append(results, *page)
+	}
+	writeJSON(w, http.StatusOK, list{Object: "list", Results: results, NextCursor: next, HasMore: next != nil})
+}
+
+func (s *Server) retrievePage(w http.ResponseWriter, id string) {
+	page := s.page(id)
+	if page == nil {
+		notFound(w, "page", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) createPage(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Parent     *notion.Parent                 `json:"parent"`
+		Properties map[string]notion.PageProperty `json:"properties"`
+		Children   []notion.Block                 `json:"children"`
+	}
+	if !decode(w, r, &body) || !checkChildren(w, "body.children", body.Children, 0) {
+		return
+	}
+
+	switch {
+	case body.Parent == nil:
+		writeError(w, http.StatusBadRequest, "", "body.parent should be defined.")
+		return
+	case body.Parent.Database != nil:
+		db := s.database(body.Parent.Database.DatabaseID)
+		if db == nil {
+			notFound(w, "database", body.Parent.Database.DatabaseID)
+			return
+		}
+		if !s.checkProperties(w, db, body.Properties) {
+			return
+		}
+	case body.Parent.Page != nil:
+		if s.page(body.Parent.Page.PageID) == nil {
+			notFound(w, "page", body.Parent.Page.PageID)
+			return
+		}
+		for name, property := range body.Properties {
+			if property.Type == "" {
+				property.Type = "title"
+			}
+			body.Properties[name] = property
+		}
+	}
+
+	page := s.addPage(notion.Page{Parent: body.Parent, Properties: body.Properties})
+	if parentID, ok := page.Parent.PageID(); ok {
+		s.addBlocks(parentID, []notion.Block{{
+			ID:        page.ID,
+			Type:      "child_page",
+			ChildPage: &notion.ChildPageBlock{Title: title(page)},
+		}})
+	}
+	s.addBlocks(page.ID, body.Children)
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) updatePage(w http.ResponseWriter, r *http.Request, id string) {
+	page := s.page(id)
+	if page == nil {
+		notFound(w, "page", id)
+		return
+	}
+
+	var body struct {
+		Properties map[string]notion.PageProperty `json:"properties"`
+		Archived   *bool                          `json:"archived"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	if databaseID, ok := page.Parent.DatabaseID(); ok {
+		if db := s.database(databaseID); db != nil && !s.checkProperties(w, db, body.Properties) {
+			return
+		}
+	}
+
+	for name, property := range body.Properties {
+		if property.Type == "" {
+			property.Type = page.Properties[name].Type
+		}
+		fillPlainText(property.Title)
+		fillPlainText(property.RichText)
+		page.Properties[name] = property
+	}
+	if body.Archived != nil {
+		page.Archived = *body.Archived
+	}
+	page.LastEditedTime = s.now()
+	writeJSON(w, http.StatusOK, page)
+}
+
+// checkProperties checks that properties exist in the schema of db, and fills in their IDs and types.
+func (s *Server) checkProperties(w http.ResponseWriter, db *notion.Database,
+	properties map[string]notion.PageProperty) bool {
+
+	for name, property := range properties {
+		schema, ok := db.Properties[name]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "", name+" is not a property that exists.")
+			return false
+		}
+		if property.Type != "" && property.Type != schema.Type {
+			writeError(w, http.StatusBadRequest, "",
+				fmt.Sprintf("%v is expected to be %v, not %v.", name, schema.Type, property.Type))
+			return false
+		}
+		property.ID, property.Type = schema.ID, schema.Type
+		properties[name] = property
+	}
+	return true
+}
+
+func (s *Server) retrieveBlockChildren(w http.ResponseWriter, r *http.Request, id string) {
+	if s.page(id) == nil && s.blocks[normalizeID(id)] == nil {
+		notFound(w, "block", id)
+		return
+	}
+	pageSize, ok := queryPageSize(w, r)
+	if !ok {
+		return
+	}
+
+	ids := s.children[normalizeID(id)]
+	start, end, next, ok := paginate(w, ids, r.URL.Query().Get("start_cursor"), pageSize)
+	if !ok {
+		return
+	}
+	results := make([]notion.Block, 0, end-start)
+	for _, child := range ids[start:end] {
+		results = append(results, s.block(child))
+	}
+	writeJSON(w, http.StatusOK, list{Object: "list", Results: results, NextCursor: next, HasMore: next != nil})
+}
+
+func (s *Server) appendBlockChildren(w http.ResponseWriter, r *http.Request, id string) {
+	page := s.page(id)
+	if page == nil && s.blocks[normalizeID(id)] == nil {
+		notFound(w, "block", id)
+		return
+	}
+
+	var body notion.AppendBlockChildrenBodyParams
+	if !decode(w, r, &body) || !checkChildren(w, "body.children", body.Children, 0) {
+		return
+	}
+	s.addBlocks(id, body.Children)
+
+	if page != nil {
+		page.LastEditedTime = s.now()
+		writeJSON(w, http.StatusOK, notion.Block{
+			Object:      "block",
+			ID:          page.ID,
+			Type:        "child_page",
+			HasChildren: true,
+			ChildPage:   &notion.ChildPageBlock{Title: title(page)},
+		})
+		return
+	}
+	block := s.blocks[normalizeID(id)]
+	block.LastEditedTime = s.now()
+	writeJSON(w, http.StatusOK, s.block(id))
+}
+
+// checkChildren writes a validation error if children, at path in the body, break Notion's limits on the length of
+// children lists and on how deep they nest in one request.
+func checkChildren(w http.ResponseWriter, path string, children []notion.Block, depth int) bool {
+	if len(children) > maxChildren {
+		writeError(w, http.StatusBadRequest, "",
+			fmt.Sprintf("%v.length should be ≤ %d, instead was %d.", path, maxChildren, len(children)))
+		return false
+	}
+	for i, block := range children {
+		grandchildren := block.Children()
+		if len(grandchildren) == 0 {
+			continue
+		}
+		childPath := fmt.Sprintf("%v[%d].%v.children", path, i, block.Type)
+		if depth >= maxNesting {
+			writeError(w, http.StatusBadRequest, "",
+				fmt.Sprintf("%v should not be present, children can only be nested %d levels deep.", childPath, maxNesting))
+			return false
+		}
+		if !checkChildren(w, childPath, grandchildren, depth+1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	var body notion.SearchBodyParams
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Filter != nil && body.Filter.Value != "page" && body.Filter.Value != "database" {
+		writeError(w, http.StatusBadRequest, "", `body.filter.value should be "page" or "database".`)
+		return
+	}
+
+	query := strings.ToLower(body.Query)
+	var results []notion.SearchResult
+	if body.Filter == nil || body.Filter.Value == "database" {
+		for _, db := range s.databases {
+			if strings.Contains(strings.ToLower(notion.PlainText(db.Title)), query) {
+				results = append(results, notion.SearchResult{Object: "database", Database: db})
+			}
+		}
+	}
+	if body.Filter == nil || body.Filter.Value == "page" {
+		for _, page := range s.pages {
+			if !page.Archived && strings.Contains(strings.ToLower(title(page)), query) {
+				results = append(results, notion.SearchResult{Object: "page", Page: page})
+			}
+		}
+	}
+	if body.Sort != nil {
+		sortSearchResults(results, body.Sort.Direction == "descending")
+	}
+
+	ids := make([]string, len(results))
+	for i, result := range results {
+		if result.Page != nil {
+			ids[i] = result.Page.ID
+		} else {
+			ids[i] = result.Database.ID
+		}
+	}
+	start, end, next, ok := paginate(w, ids, body.StartCursor, int(body.PageSize))
+	if !ok {
+		return
+	}
+	results = append([]notion.SearchResult{}, results[start:end]...)
+	writeJSON(w, http.StatusOK, list{Object: "list", Results: results, NextCursor: next, HasMore: next != nil})
+}
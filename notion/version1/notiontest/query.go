@@ -0,0 +1,429 @@
+package notiontest
+
+import (
+	"encoding/json"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"sort"
+	"strings"
+	"time"
+)
+
+// matches reports whether page matches the filter, a single or compound filter already checked by
+// notion.ValidateQuery.
+func (s *Server) matches(filter json.RawMessage, page *notion.Page) bool {
+	var compound struct {
+		And []json.RawMessage `json:"and"`
+		Or  []json.RawMessage `json:"or"`
+	}
+	if err := json.Unmarshal(filter, &compound); err != nil {
+		return false
+	}
+	switch {
+	case compound.And != nil:
+		for _, f := range compound.And {
+			if !s.matches(f, page) {
+				return false
+			}
+		}
+		return true
+	case compound.Or != nil:
+		for _, f := range compound.Or {
+			if s.matches(f, page) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var single notion.SingleFilter
+	if err := json.Unmarshal(filter, &single); err != nil {
+		return false
+	}
+	return s.matchesSingle(&single, page)
+}
+
+func (s *Server) matchesSingle(f *notion.SingleFilter, page *notion.Page) bool {
+	p := page.Properties[f.Property]
+	for _, c := range []*notion.TextCondition{f.Title, f.RichText, f.URL, f.Email, f.Phone} {
+		if c != nil {
+			return textMatches(c, propertyText(p))
+		}
+	}
+
+	switch {
+	case f.Number != nil:
+		return numberMatches(f.Number, p.Number)
+	case f.Checkbox != nil:
+		return checkboxMatches(f.Checkbox, p.Checkbox)
+	case f.Select != nil:
+		name := ""
+		if p.Select != nil {
+			name = p.Select.Name
+		}
+		return selectMatches(f.Select, name)
+	case f.MultiSelect != nil:
+		names := make([]string, len(p.MultiSelect))
+		for i, option := range p.MultiSelect {
+			names[i] = option.Name
+		}
+		return listMatches(f.MultiSelect.Contains, f.MultiSelect.DoesNotContain, f.MultiSelect.IsEmpty,
+			f.MultiSelect.IsNotEmpty, names, strings.EqualFold)
+	case f.Date != nil:
+		start := ""
+		if p.Date != nil {
+			start = p.Date.Start
+		}
+		return s.dateMatches(f.Date, start)
+	case f.CreatedTime != nil:
+		return s.dateMatches(f.CreatedTime, firstNonEmpty(p.CreatedTime, page.CreatedTime))
+	case f.LastEditedTime != nil:
+		return s.dateMatches(f.LastEditedTime, firstNonEmpty(p.LastEditedTime, page.LastEditedTime))
+	case f.People != nil:
+		return peopleMatches(f.People, p.People)
+	case f.CreatedBy != nil:
+		return peopleMatches(f.CreatedBy, userList(p.CreatedBy))
+	case f.LastEditedBy != nil:
+		return peopleMatches(f.LastEditedBy, userList(p.LastEditedBy))
+	case f.Files != nil:
+		return !f.Files.IsEmpty && !f.Files.IsNotEmpty || f.Files.IsEmpty == (len(p.Files) == 0)
+	case f.Relation != nil:
+		ids := make([]string, len(p.Relation))
+		for i, relation := range p.Relation {
+			ids[i] = relation.ID
+		}
+		return listMatches(f.Relation.Contains, f.Relation.DoesNotContain, f.Relation.IsEmpty, f.Relation.IsNotEmpty,
+			ids, sameID)
+	case f.Formula != nil:
+		return s.formulaMatches(f.Formula, p.Formula)
+	}
+	return true
+}
+
+func (s *Server) formulaMatches(c *notion.FormulaCondition, value *notion.FormulaProperty) bool {
+	if value == nil {
+		value = &notion.FormulaProperty{}
+	}
+	switch {
+	case c.Text != nil:
+		return textMatches(c.Text, value.String)
+	case c.Number != nil:
+		return numberMatches(c.Number, value.Number)
+	case c.Checkbox != nil:
+		return checkboxMatches(c.Checkbox, value.Boolean)
+	case c.Date != nil:
+		start := ""
+		if value.Date != nil {
+			start = value.Date.Start
+		}
+		return s.dateMatches(c.Date, start)
+	}
+	return true
+}
+
+// textMatches reports whether v matches c. Like Notion's, comparisons other than equality ignore case.
+func textMatches(c *notion.TextCondition, v string) bool {
+	lower := strings.ToLower(v)
+	switch {
+	case c.Equals != "" && v != c.Equals,
+		c.DoesNotEqual != "" && v == c.DoesNotEqual,
+		c.Contains != "" && !strings.Contains(lower, strings.ToLower(c.Contains)),
+		c.DoesNotContain != "" && strings.Contains(lower, strings.ToLower(c.DoesNotContain)),
+		c.StartsWith != "" && !strings.HasPrefix(lower, strings.ToLower(c.StartsWith)),
+		c.EndsWith != "" && !strings.HasSuffix(lower, strings.ToLower(c.EndsWith)),
+		c.IsEmpty && v != "",
+		c.IsNotEmpty && v == "":
+		return false
+	}
+	return true
+}
+
+func numberMatches(c *notion.NumberCondition, v *float64) bool {
+	if v == nil {
+		return !c.IsNotEmpty && c.Equals == nil && c.GreaterThan == nil && c.LessThan == nil &&
+			c.GreaterThanOrEqualTo == nil && c.LessThanOrEqualTo == nil
+	}
+	switch {
+	case c.Equals != nil && *v != *c.Equals,
+		c.DoesNotEqual != nil && *v == *c.DoesNotEqual,
+		c.GreaterThan != nil && *v <= *c.GreaterThan,
+		c.LessThan != nil && *v >= *c.LessThan,
+		c.GreaterThanOrEqualTo != nil && *v < *c.GreaterThanOrEqualTo,
+		c.LessThanOrEqualTo != nil && *v > *c.LessThanOrEqualTo,
+		c.IsEmpty:
+		return false
+	}
+	return true
+}
+
+func checkboxMatches(c *notion.CheckboxCondition, v bool) bool {
+	return (c.Equals == nil || v == *c.Equals) && (c.DoesNotEqual == nil || v != *c.DoesNotEqual)
+}
+
+func selectMatches(c *notion.SelectCondition, v string) bool {
+	switch {
+	case c.Equals != "" && !strings.EqualFold(v, c.Equals),
+		c.DoesNotEqual != "" && strings.EqualFold(v, c.DoesNotEqual),
+		c.IsEmpty && v != "",
+		c.IsNotEmpty && v == "":
+		return false
+	}
+	return true
+}
+
+func peopleMatches(c *notion.PeopleCondition, users []notion.User) bool {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return listMatches(c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty, ids, sameID)
+}
+
+// listMatches evaluates the conditions on a list of values, comparing them with equal.
+func listMatches(contains, doesNotContain string, isEmpty, isNotEmpty bool, values []string,
+	equal func(a, b string) bool) bool {
+
+	has := func(value string) bool {
+		for _, v := range values {
+			if equal(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case contains != "" && !has(contains),
+		doesNotContain != "" && has(doesNotContain),
+		isEmpty && len(values) > 0,
+		isNotEmpty && len(values) == 0:
+		return false
+	}
+	return true
+}
+
+// dateMatches reports whether the date v matches c. Dates without a time in c compare whole days.
+func (s *Server) dateMatches(c *notion.DateCondition, v string) bool {
+	if v == "" {
+		return c.IsEmpty
+	}
+	if c.IsEmpty {
+		return false
+	}
+	value, ok := parseDate(v)
+	if !ok {
+		return false
+	}
+
+	compare := func(condition string, ok func(cmp int) bool) bool {
+		if condition == "" {
+			return true
+		}
+		date, valid := parseDate(condition)
+		if !valid {
+			return false
+		}
+		a, b := value, date
+		if len(condition) == len("2006-01-02") {
+			a, b = day(a), day(b)
+		}
+		switch {
+		case a.Before(b):
+			return ok(-1)
+		case a.After(b):
+			return ok(1)
+		}
+		return ok(0)
+	}
+	if !compare(c.Equals, func(cmp int) bool { return cmp == 0 }) ||
+		!compare(c.Before, func(cmp int) bool { return cmp < 0 }) ||
+		!compare(c.After, func(cmp int) bool { return cmp > 0 }) ||
+		!compare(c.OnOrBefore, func(cmp int) bool { return cmp <= 0 }) ||
+		!compare(c.OnOrAfter, func(cmp int) bool { return cmp >= 0 }) {
+		return false
+	}
+
+	now := s.Now()
+	within := func(condition interface{}, from, to time.Time) bool {
+		return condition == nil || !value.Before(from) && !value.After(to)
+	}
+	return within(c.PastWeek, now.AddDate(0, 0, -7), now) &&
+		within(c.PastMonth, now.AddDate(0, -1, 0), now) &&
+		within(c.PastYear, now.AddDate(-1, 0, 0), now) &&
+		within(c.NextWeek, now, now.AddDate(0, 0, 7)) &&
+		within(c.NextMonth, now, now.AddDate(0, 1, 0)) &&
+		within(c.NextYear, now, now.AddDate(1, 0, 0))
+}
+
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sortPages sorts pages by sorts, in order of precedence. Empty values sort last whatever the direction.
+func sortPages(pages []*notion.Page, db *notion.Database, sorts []notion.SortCriteria) {
+	sort.SliceStable(pages, func(i, j int) bool {
+		for _, criteria := range sorts {
+			a, b := sortValue(pages[i], db, criteria), sortValue(pages[j], db, criteria)
+			cmp := compareValues(a, b)
+			if cmp == 0 {
+				continue
+			}
+			if a != nil && b != nil && criteria.Direction == "descending" {
+				cmp = -cmp
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// sortValue returns the value page is sorted by, a string, float64 or bool, or nil if it is empty.
+func sortValue(page *notion.Page, db *notion.Database, criteria notion.SortCriteria) interface{} {
+	switch criteria.Timestamp {
+	case "created_time":
+		return page.CreatedTime
+	case "last_edited_time":
+		return page.LastEditedTime
+	}
+
+	p := page.Properties[criteria.Property]
+	switch db.Properties[criteria.Property].Type {
+	case "number":
+		if p.Number == nil {
+			return nil
+		}
+		return *p.Number
+	case "checkbox":
+		return p.Checkbox
+	case "date":
+		if p.Date == nil || p.Date.Start == "" {
+			return nil
+		}
+		if t, ok := parseDate(p.Date.Start); ok {
+			return t.UTC().Format(time.RFC3339Nano)
+		}
+		return p.Date.Start
+	case "multi_select":
+		if len(p.MultiSelect) == 0 {
+			return nil
+		}
+		return p.MultiSelect[0].Name
+	case "created_time":
+		return firstNonEmpty(p.CreatedTime, page.CreatedTime)
+	case "last_edited_time":
+		return firstNonEmpty(p.LastEditedTime, page.LastEditedTime)
+	}
+	if text := propertyText(p); text != "" {
+		return strings.ToLower(text)
+	}
+	return nil
+}
+
+// compareValues compares two sort values of the same type, with nil after everything else.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	switch a := a.(type) {
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case bool:
+		b := b.(bool)
+		switch {
+		case !a && b:
+			return -1
+		case a && !b:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+	return 0
+}
+
+// sortSearchResults sorts search results by their last edited time.
+func sortSearchResults(results []notion.SearchResult, descending bool) {
+	lastEdited := func(result notion.SearchResult) string {
+		if result.Page != nil {
+			return result.Page.LastEditedTime
+		}
+		return result.Database.LastEditedTime
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if descending {
+			return lastEdited(results[i]) > lastEdited(results[j])
+		}
+		return lastEdited(results[i]) < lastEdited(results[j])
+	})
+}
+
+// propertyText returns the text of properties that hold text, and the name of select properties.
+func propertyText(p notion.PageProperty) string {
+	switch {
+	case len(p.Title) > 0:
+		return notion.PlainText(p.Title)
+	case len(p.RichText) > 0:
+		return notion.PlainText(p.RichText)
+	case p.URL != "":
+		return p.URL
+	case p.Email != "":
+		return p.Email
+	case p.PhoneNumber != "":
+		return p.PhoneNumber
+	case p.Select != nil:
+		return p.Select.Name
+	case p.Formula != nil:
+		return p.Formula.String
+	}
+	return ""
+}
+
+// title returns the title of page.
+func title(page *notion.Page) string {
+	for _, property := range page.Properties {
+		if property.Type == "title" || len(property.Title) > 0 {
+			return notion.PlainText(property.Title)
+		}
+	}
+	return ""
+}
+
+func userList(user *notion.User) []notion.User {
+	if user == nil {
+		return nil
+	}
+	return []notion.User{*user}
+}
+
+func sameID(a, b string) bool {
+	return normalizeID(a) == normalizeID(b)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,501 @@
+// Package notiontest runs an in-memory fake of the Notion API for tests.
+//
+// A Server keeps users, databases, pages and blocks in memory and answers the endpoints of the API the client
+// covers, including database queries with filters, sorts and pagination, block children and search, so tests can
+// run offline against realistic responses:
+//
+//	server := notiontest.NewServer()
+//	defer server.Close()
+//
+//	db := server.AddDatabase(notion.Database{...})
+//	client := server.Client()
+//	pages, _, err := client.Databases.QueryDatabase(ctx, db.ID, params)
+//
+// Fail makes the server fail requests, e.g. with 429 or 404 responses, to test error handling.
+package notiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxPageSize is the largest page_size the server accepts, and the default.
+	maxPageSize = 100
+
+	// maxChildren is the most blocks the server accepts in one children list.
+	maxChildren = 100
+
+	// maxNesting is how many levels of children the server accepts below the children of a request.
+	maxNesting = 2
+)
+
+// Server is a fake Notion API. Its methods are safe to call while it serves requests.
+type Server struct {
+	// URL is the base URL of the API, to pass to notion.WithBaseURL.
+	URL string
+
+	// Now returns the time used for created and edited times and relative date filters. Defaults to time.Now.
+	Now func() time.Time
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	ids       int
+	requests  int
+	users     []notion.User
+	databases []*notion.Database
+	pages     []*notion.Page
+	blocks    map[string]*notion.Block
+	children  map[string][]string
+	faults    []*Fault
+}
+
+// Fault makes the server fail requests instead of answering them.
+type Fault struct {
+	// Method is the method of the requests that fail, e.g. "POST". Empty matches any method.
+	Method string
+
+	// Path is a path.Match pattern for the paths of the requests that fail, relative to URL and without a trailing
+	// slash, e.g. "databases/*/query" or "search". Empty matches any path.
+	Path string
+
+	// Status is the HTTP status of the failed responses. Defaults to 500.
+	Status int
+
+	// Code is the error code of the failed responses, e.g. "rate_limited". Defaults to the code Notion uses for
+	// Status.
+	Code string
+
+	// Message is the error message of the failed responses. Defaults to the text of Status.
+	Message string
+
+	// RetryAfter, when set, is sent in the Retry-After header, in whole seconds.
+	RetryAfter time.Duration
+
+	// Times is the number of matching requests that fail, after which the fault is removed. 0 fails every matching
+	// request.
+	Times int
+}
+
+// statusCodes are the error codes Notion uses for each status.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "validation_error",
+	http.StatusUnauthorized:        "unauthorized",
+	http.StatusForbidden:           "restricted_resource",
+	http.StatusNotFound:            "object_not_found",
+	http.StatusConflict:            "conflict_error",
+	http.StatusTooManyRequests:     "rate_limited",
+	http.StatusInternalServerError: "internal_server_error",
+	http.StatusServiceUnavailable:  "service_unavailable",
+}
+
+// NewServer starts a Server with no data. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Now:      time.Now,
+		blocks:   map[string]*notion.Block{},
+		children: map[string][]string{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL + "/v1/"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a Client that sends its requests to the server, configured with opts.
+func (s *Server) Client(opts ...notion.Option) *notion.Client {
+	return notion.NewClient(s.server.Client(), "secret_notiontest", append([]notion.Option{notion.WithBaseURL(s.URL)},
+		opts...)...)
+}
+
+// Fail adds a fault. Faults are matched in the order they are added.
+func (s *Server) Fail(fault Fault) {
+	if fault.Status == 0 {
+		fault.Status = http.StatusInternalServerError
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests the server has received, failed ones included.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// AddUser adds user, giving it an ID if it has none, and returns it as the server stores it.
+func (s *Server) AddUser(user notion.User) notion.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user.Object = "user"
+	if user.ID == "" {
+		user.ID = s.newID()
+	}
+	if user.Type == "" {
+		user.Type = "person"
+	}
+	s.users = append(s.users, user)
+	return user
+}
+
+// AddDatabase adds db, giving it an ID and timestamps if it has none, and returns it as the server stores it.
+// The keys of db.Properties are the names of its properties.
+func (s *Server) AddDatabase(db notion.Database) notion.Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db.Object = "database"
+	if db.ID == "" {
+		db.ID = s.newID()
+	}
+	s.setTimes(&db.CreatedTime, &db.LastEditedTime)
+	fillPlainText(db.Title)
+	properties := make(map[string]notion.PropertyObj, len(db.Properties))
+	for name, property := range db.Properties {
+		if property.ID == "" {
+			property.ID = s.newID()[:8]
+		}
+		properties[name] = property
+	}
+	db.Properties = properties
+
+	s.databases = append(s.databases, &db)
+	return db
+}
+
+// AddPage adds page, giving it an ID and timestamps if it has none, and returns it as the server stores it. Unlike
+// creating a page through the API, it doesn't check page against the schema of its database.
+func (s *Server) AddPage(page notion.Page) notion.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addPage(page)
+}
+
+// AddBlocks appends blocks and their children to the children of the page or block with parentID, giving them IDs
+// if they have none, and returns them as the server stores them.
+func (s *Server) AddBlocks(parentID string, blocks ...notion.Block) []notion.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.addBlocks(parentID, blocks)
+	added := make([]notion.Block, len(ids))
+	for i, id := range ids {
+		added[i] = s.tree(id)
+	}
+	return added
+}
+
+// Page returns the page with id, as the server stores it.
+func (s *Server) Page(id string) (notion.Page, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page := s.page(id)
+	if page == nil {
+		return notion.Page{}, false
+	}
+	return *page, true
+}
+
+// Blocks returns the children of the page or block with parentID, with their own children.
+func (s *Server) Blocks(parentID string) []notion.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var blocks []notion.Block
+	for _, id := range s.children[normalizeID(parentID)] {
+		blocks = append(blocks, s.tree(id))
+	}
+	return blocks
+}
+
+// newID returns a new ID, in the format of Notion's.
+func (s *Server) newID() string {
+	s.ids++
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.ids, s.ids)
+}
+
+func (s *Server) now() string {
+	return s.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func (s *Server) setTimes(created, lastEdited *string) {
+	if *created == "" {
+		*created = s.now()
+	}
+	if *lastEdited == "" {
+		*lastEdited = *created
+	}
+}
+
+func (s *Server) addPage(page notion.Page) *notion.Page {
+	page.Object = "page"
+	if page.ID == "" {
+		page.ID = s.newID()
+	}
+	s.setTimes(&page.CreatedTime, &page.LastEditedTime)
+	properties := make(map[string]notion.PageProperty, len(page.Properties))
+	for name, property := range page.Properties {
+		fillPlainText(property.Title)
+		fillPlainText(property.RichText)
+		properties[name] = property
+	}
+	page.Properties = properties
+
+	s.pages = append(s.pages, &page)
+	return &page
+}
+
+// addBlocks stores blocks and their children under parentID and returns their IDs.
+func (s *Server) addBlocks(parentID string, blocks []notion.Block) []string {
+	parentID = normalizeID(parentID)
+	ids := make([]string, len(blocks))
+	for i := range blocks {
+		block := clone(blocks[i])
+		block.Object = "block"
+		if block.ID == "" {
+			block.ID = s.newID()
+		}
+		s.setTimes(&block.CreatedTime, &block.LastEditedTime)
+		children := block.Children()
+		block.SetChildren(nil)
+		fillPlainText(blockText(block))
+
+		id := normalizeID(block.ID)
+		s.blocks[id] = block
+		s.children[parentID] = append(s.children[parentID], id)
+		s.addBlocks(id, children)
+		ids[i] = block.ID
+	}
+	return ids
+}
+
+// block returns the block with id as the API does, with HasChildren set and without its children.
+func (s *Server) block(id string) notion.Block {
+	id = normalizeID(id)
+	block := *clone(*s.blocks[id])
+	block.HasChildren = len(s.children[id]) > 0
+	return block
+}
+
+// tree returns the block with id and its children.
+func (s *Server) tree(id string) notion.Block {
+	block := s.block(id)
+	var children []notion.Block
+	for _, child := range s.children[normalizeID(id)] {
+		children = append(children, s.tree(child))
+	}
+	block.SetChildren(children)
+	return block
+}
+
+func (s *Server) database(id string) *notion.Database {
+	for _, db := range s.databases {
+		if normalizeID(db.ID) == normalizeID(id) {
+			return db
+		}
+	}
+	return nil
+}
+
+func (s *Server) page(id string) *notion.Page {
+	for _, page := range s.pages {
+		if normalizeID(page.ID) == normalizeID(id) {
+			return page
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of block, whose content the server can change without changing block's.
+func clone(block notion.Block) *notion.Block {
+	data, err := json.Marshal(block)
+	if err != nil {
+		panic(err)
+	}
+	copied := new(notion.Block)
+	if err := json.Unmarshal(data, copied); err != nil {
+		panic(err)
+	}
+	return copied
+}
+
+// normalizeID removes the dashes of id, since Notion accepts IDs with and without them.
+func normalizeID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// fillPlainText sets the plain text of rich text, which Notion fills in responses.
+func fillPlainText(richText []notion.RichText) {
+	for i := range richText {
+		rt := &richText[i]
+		switch {
+		case rt.PlainText != "":
+		case rt.Text != nil:
+			rt.PlainText = rt.Text.Content
+			if rt.Text.Link != nil {
+				rt.Href = rt.Text.Link.URL
+			}
+		case rt.Equation != nil:
+			rt.PlainText = rt.Equation.Expression
+		}
+		if rt.Type == "" {
+			rt.Type = "text"
+		}
+		if rt.Annotations == nil {
+			rt.Annotations = &notion.Annotations{Color: "default"}
+		}
+	}
+}
+
+// blockText returns the text of block, for the block types that have one.
+func blockText(block *notion.Block) []notion.RichText {
+	switch {
+	case block.Paragraph != nil:
+		return block.Paragraph.Text
+	case block.HeadingOne != nil:
+		return block.HeadingOne.Text
+	case block.HeadingTwo != nil:
+		return block.HeadingTwo.Text
+	case block.HeadingThree != nil:
+		return block.HeadingThree.Text
+	case block.BulletedListItem != nil:
+		return block.BulletedListItem.Text
+	case block.NumberedListItem != nil:
+		return block.NumberedListItem.Text
+	case block.ToDo != nil:
+		return block.ToDo.Text
+	case block.Toggle != nil:
+		return block.Toggle.Text
+	case block.Quote != nil:
+		return block.Quote.Text
+	case block.Callout != nil:
+		return block.Callout.Text
+	case block.Code != nil:
+		return block.Code.Text
+	}
+	return nil
+}
+
+// serveHTTP routes requests to the handlers of the endpoints, unless a fault fails them.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	w.Header().Set("X-Notion-Request-Id", strconv.Itoa(s.requests))
+
+	// Services send requests to their root path with a trailing slash, e.g. "pages/".
+	route := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	if s.fail(w, r.Method, route) {
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "", "API token is invalid.")
+		return
+	}
+
+	segments := strings.Split(route, "/")
+	switch {
+	case r.Method == http.MethodGet && route == "users":
+		s.listUsers(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "users":
+		s.retrieveUser(w, segments[1])
+	case r.Method == http.MethodGet && route == "databases":
+		s.listDatabases(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "databases":
+		s.retrieveDatabase(w, segments[1])
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[0] == "databases" && segments[2] == "query":
+		s.queryDatabase(w, r, segments[1])
+	case r.Method == http.MethodPost && route == "pages":
+		s.createPage(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "pages":
+		s.retrievePage(w, segments[1])
+	case r.Method == http.MethodPatch && len(segments) == 2 && segments[0] == "pages":
+		s.updatePage(w, r, segments[1])
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "blocks" && segments[2] == "children":
+		s.retrieveBlockChildren(w, r, segments[1])
+	case r.Method == http.MethodPatch && len(segments) == 3 && segments[0] == "blocks" && segments[2] == "children":
+		s.appendBlockChildren(w, r, segments[1])
+	case r.Method == http.MethodPost && route == "search":
+		s.search(w, r)
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_request_url", "Invalid request URL.")
+	}
+}
+
+// fail writes the response of the first fault that matches the request, if any.
+func (s *Server) fail(w http.ResponseWriter, method, route string) bool {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if matched, _ := path.Match(fault.Path, route); fault.Path != "" && !matched {
+			continue
+		}
+
+		if fault.Times > 0 {
+			if fault.Times--; fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+		}
+		writeError(w, fault.Status, fault.Code, fault.Message)
+		return true
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response, with the code and message Notion uses for status unless they are given.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	if code == "" {
+		code = statusCodes[status]
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	writeJSON(w, status, notion.APIError{Object: "error", Status: int32(status), Code: code, Message: message})
+}
+
+func notFound(w http.ResponseWriter, object, id string) {
+	writeError(w, http.StatusNotFound, "", fmt.Sprintf("Could not find %v with ID: %v.", object, id))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Error parsing JSON body.")
+		return false
+	}
+	return true
+}
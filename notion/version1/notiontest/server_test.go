@@ -0,0 +1,305 @@
+package notiontest_test
+
+import (
+	"context"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/filter"
+	"github.com/oyekanmiayo/go-notion/notion/version1/notiontest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func text(content string) []notion.RichText {
+	return []notion.RichText{{Type: "text", Text: &notion.Text{Content: content}}}
+}
+
+// newTasks starts a server with a tasks database holding three pages.
+func newTasks(t *testing.T) (*notiontest.Server, notion.Database) {
+	server := notiontest.NewServer()
+	t.Cleanup(server.Close)
+
+	db := server.AddDatabase(notion.Database{
+		Title: text("Tasks"),
+		Properties: map[string]notion.PropertyObj{
+			"Name":     {Type: "title"},
+			"Estimate": {Type: "number"},
+			"Done":     {Type: "checkbox"},
+			"Status":   {Type: "select"},
+			"Due":      {Type: "date"},
+		},
+	})
+	for _, task := range []struct {
+		name     string
+		estimate float64
+		done     bool
+		status   string
+		due      string
+	}{
+		{"Write docs", 3, true, "Done", "2021-06-01"},
+		{"Release", 1, false, "Doing", "2021-06-10"},
+		{"Announce release", 2, false, "Todo", "2021-06-20"},
+	} {
+		server.AddPage(notion.Page{
+			Parent: notion.NewDatabaseParent(db.ID),
+			Properties: map[string]notion.PageProperty{
+				"Name":     {Type: "title", Title: text(task.name)},
+				"Estimate": {Type: "number", Number: notion.Float64(task.estimate)},
+				"Done":     {Type: "checkbox", Checkbox: task.done},
+				"Status":   {Type: "select", Select: &notion.SelectProperty{Name: task.status}},
+				"Due":      {Type: "date", Date: &notion.DateProperty{Start: task.due}},
+			},
+		})
+	}
+	return server, db
+}
+
+func names(pages []notion.Page) []string {
+	var names []string
+	for _, page := range pages {
+		names = append(names, page.Properties["Name"].Title[0].PlainText)
+	}
+	return names
+}
+
+func TestServer_QueryDatabase(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client()
+
+	cases := []struct {
+		params   *notion.QueryDatabaseBodyParams
+		expected []string
+	}{
+		{&notion.QueryDatabaseBodyParams{}, []string{"Write docs", "Release", "Announce release"}},
+		{
+			&notion.QueryDatabaseBodyParams{Filter: filter.Title("Name").Contains("RELEASE")},
+			[]string{"Release", "Announce release"},
+		},
+		{
+			&notion.QueryDatabaseBodyParams{
+				Filter: filter.Checkbox("Done").Equals(false).And(filter.Number("Estimate").GreaterThan(1)),
+			},
+			[]string{"Announce release"},
+		},
+		{
+			&notion.QueryDatabaseBodyParams{
+				Filter: filter.Or(filter.Select("Status").Equals("Done"), filter.Date("Due").After("2021-06-15")),
+				Sorts:  []notion.SortCriteria{{Property: "Estimate", Direction: "descending"}},
+			},
+			[]string{"Write docs", "Announce release"},
+		},
+		{
+			&notion.QueryDatabaseBodyParams{Sorts: []notion.SortCriteria{{Property: "Name", Direction: "ascending"}}},
+			[]string{"Announce release", "Release", "Write docs"},
+		},
+	}
+	for _, c := range cases {
+		response, _, err := client.Databases.QueryDatabase(context.Background(), db.ID, c.params)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, names(response.Results))
+	}
+}
+
+func TestServer_QueryDatabase_Pagination(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client()
+
+	response, _, err := client.Databases.QueryDatabase(context.Background(), db.ID,
+		&notion.QueryDatabaseBodyParams{PageSize: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Write docs", "Release"}, names(response.Results))
+	assert.True(t, response.HasMore)
+
+	var pages []notion.Page
+	it := client.Databases.QueryIter(context.Background(), db.ID, &notion.QueryDatabaseBodyParams{PageSize: 1})
+	for it.Next() {
+		pages = append(pages, it.Value())
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"Write docs", "Release", "Announce release"}, names(pages))
+	assert.Equal(t, 4, server.Requests())
+}
+
+func TestServer_QueryDatabase_Invalid(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client()
+
+	_, _, err := client.Databases.QueryDatabase(context.Background(), db.ID,
+		&notion.QueryDatabaseBodyParams{Filter: filter.Text("Missing").Contains("x")})
+	assert.True(t, notion.IsValidationError(err))
+
+	_, _, err = client.Databases.QueryDatabase(context.Background(), "unknown", nil)
+	assert.True(t, notion.IsNotFound(err))
+}
+
+func TestServer_Pages(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client()
+	ctx := context.Background()
+
+	page, _, err := client.Pages.CreatePage(ctx, &notion.CreatePageBodyParams{
+		Parent: notion.NewDatabaseParent(db.ID),
+		Properties: map[string]notion.PageProperty{
+			"Name":     {Title: text("Celebrate")},
+			"Estimate": {Type: "number", Number: notion.Float64(5)},
+		},
+		Children: []notion.Block{
+			{Object: "block", Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("With cake")}},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Celebrate", page.Properties["Name"].Title[0].PlainText)
+	assert.Equal(t, "title", page.Properties["Name"].Type)
+
+	updated, _, err := client.Pages.UpdatePageProperties(ctx, page.ID, &notion.UpdatePagePropertiesBodyParams{
+		Properties: map[string]notion.PageProperty{"Done": {Type: "checkbox", Checkbox: true}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, updated.Properties["Done"].Checkbox)
+
+	retrieved, _, err := client.Pages.RetrievePage(ctx, page.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, *retrieved.Properties["Estimate"].Number)
+	assert.True(t, retrieved.Properties["Done"].Checkbox)
+
+	response, _, err := client.Databases.QueryDatabase(ctx, db.ID,
+		&notion.QueryDatabaseBodyParams{Filter: filter.Checkbox("Done").Equals(true)})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Write docs", "Celebrate"}, names(response.Results))
+
+	blocks := server.Blocks(page.ID)
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, "With cake", blocks[0].Paragraph.Text[0].PlainText)
+	}
+
+	_, _, err = client.Pages.CreatePage(ctx, &notion.CreatePageBodyParams{
+		Parent:     notion.NewDatabaseParent(db.ID),
+		Properties: map[string]notion.PageProperty{"Missing": {Type: "rich_text"}},
+	})
+	assert.True(t, notion.IsValidationError(err))
+}
+
+func TestServer_Blocks(t *testing.T) {
+	server := notiontest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	page := server.AddPage(notion.Page{
+		Parent:     notion.NewWorkspaceParent(),
+		Properties: map[string]notion.PageProperty{"title": {Type: "title", Title: text("Notes")}},
+	})
+	_, _, err := client.Blocks.AppendBlockChildren(ctx, page.ID, &notion.AppendBlockChildrenBodyParams{
+		Children: []notion.Block{
+			{Object: "block", Type: "toggle", Toggle: &notion.ToggleBlock{Text: text("More"), Children: []notion.Block{
+				{Object: "block", Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("Hidden")}},
+			}}},
+			{Object: "block", Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: text("Shown")}},
+		},
+	})
+	assert.Nil(t, err)
+
+	children, _, err := client.Blocks.RetrieveBlockChildren(ctx, page.ID, &notion.RetrieveBlockChildrenParams{PageSize: 1})
+	assert.Nil(t, err)
+	if assert.Len(t, children.Results, 1) {
+		assert.True(t, children.Results[0].HasChildren)
+		assert.Nil(t, children.Results[0].Toggle.Children)
+	}
+	assert.True(t, children.HasMore)
+
+	tree, err := client.Blocks.RetrieveBlockTree(ctx, page.ID, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, server.Blocks(page.ID), tree)
+	if assert.Len(t, tree, 2) {
+		assert.Equal(t, "Hidden", tree[0].Toggle.Children[0].Paragraph.Text[0].PlainText)
+	}
+
+	_, _, err = client.Blocks.RetrieveBlockChildren(ctx, "unknown", nil)
+	assert.True(t, notion.IsNotFound(err))
+}
+
+func TestServer_UsersAndSearch(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client()
+	ctx := context.Background()
+
+	ada := server.AddUser(notion.User{Name: "Ada"})
+	server.AddUser(notion.User{Name: "Grace"})
+
+	user, _, err := client.Users.RetrieveUser(ctx, ada.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Ada", user.Name)
+
+	var users []string
+	it := client.Users.ListIter(ctx, &notion.ListUsersQueryParams{PageSize: 1})
+	for it.Next() {
+		users = append(users, it.Value().Name)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"Ada", "Grace"}, users)
+
+	response, _, err := client.Search.Search(ctx, &notion.SearchBodyParams{Query: "task"})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Results, 1) {
+		assert.Equal(t, db.ID, response.Results[0].Database.ID)
+	}
+
+	pages, _, err := client.Search.SearchPage(ctx, &notion.SearchBodyParams{Query: "release"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Release", "Announce release"}, names(pages.Results))
+}
+
+func TestServer_Fail(t *testing.T) {
+	server, db := newTasks(t)
+	client := server.Client(notion.WithRetryPolicy(&notion.RetryPolicy{MaxAttempts: 3}))
+	ctx := context.Background()
+
+	server.Fail(notiontest.Fault{
+		Method:     http.MethodPost,
+		Path:       "databases/*/query",
+		Status:     http.StatusTooManyRequests,
+		RetryAfter: time.Millisecond,
+		Times:      2,
+	})
+	_, _, err := client.Databases.QueryDatabase(ctx, db.ID, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, server.Requests())
+
+	server.Fail(notiontest.Fault{Path: "databases/*", Status: http.StatusNotFound})
+	_, _, err = client.Databases.RetrieveDatabase(ctx, db.ID)
+	assert.True(t, notion.IsNotFound(err))
+	_, _, err = client.Databases.RetrieveDatabase(ctx, db.ID)
+	assert.True(t, notion.IsNotFound(err))
+
+	server.ClearFaults()
+	_, _, err = client.Databases.RetrieveDatabase(ctx, db.ID)
+	assert.Nil(t, err)
+
+	server.Fail(notiontest.Fault{Path: "users"})
+	_, _, err = server.Client().Users.ListUsers(ctx, nil)
+	var apiErr notion.APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, int32(http.StatusInternalServerError), apiErr.Status)
+		assert.Equal(t, "internal_server_error", apiErr.Code)
+	}
+}
+
+func TestServer_Fail_RootRoutes(t *testing.T) {
+	server, _ := newTasks(t)
+	client := server.Client()
+	ctx := context.Background()
+
+	server.Fail(notiontest.Fault{Path: "search", Status: http.StatusTooManyRequests})
+	server.Fail(notiontest.Fault{Method: http.MethodGet, Path: "users", Status: http.StatusNotFound})
+
+	_, _, err := client.Search.Search(ctx, &notion.SearchBodyParams{})
+	assert.True(t, notion.IsRateLimited(err))
+	_, _, err = client.Users.ListUsers(ctx, nil)
+	assert.True(t, notion.IsNotFound(err))
+
+	// Faults on root routes don't match the objects below them.
+	_, _, err = client.Users.RetrieveUser(ctx, server.AddUser(notion.User{Name: "Ada"}).ID)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,87 @@
+package version1
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Option configures a Client created by NewClient or AuthClient.
+type Option func(*options)
+
+type options struct {
+	baseURL     string
+	version     string
+	userAgent   string
+	header      http.Header
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	middleware  []Middleware
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		baseURL: notionAPI,
+		version: notionVersion,
+		header:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithBaseURL sends requests to baseURL instead of https://api.notion.com/v1/, e.g. to use a local stand-in
+// for Notion during tests.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		// Service paths are resolved relative to the base URL, so it has to end with a slash.
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		o.baseURL = baseURL
+	}
+}
+
+// WithNotionVersion sets the Notion-Version header sent with every request.
+// https://developers.notion.com/reference/versioning
+func WithNotionVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request. It can be used more than once.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithRetryPolicy sets the Client's RetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the Client's RateLimiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware wraps every request made by the Client's services with middleware. It can be used more than once;
+// the first middleware added is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
@@ -0,0 +1,90 @@
+package version1_test
+
+import (
+	"context"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClient_DefaultHeaders(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer 0000", r.Header.Get("Authorization"))
+		assert.Equal(t, "2021-05-13", r.Header.Get("Notion-Version"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUserJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/stand-in/users/123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer 0000", r.Header.Get("Authorization"))
+		assert.Equal(t, "2022-02-22", r.Header.Get("Notion-Version"))
+		assert.Equal(t, "my-integration/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, []string{"a", "b"}, r.Header["X-Extra"])
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUserJSON)
+	})
+
+	client := notion.NewClient(http.DefaultClient, "0000",
+		notion.WithBaseURL(server.URL+"/stand-in"),
+		notion.WithNotionVersion("2022-02-22"),
+		notion.WithUserAgent("my-integration/1.0"),
+		notion.WithHeader("X-Extra", "a"),
+		notion.WithHeader("X-Extra", "b"),
+	)
+	resp, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+	assert.Equal(t, testUser, resp)
+}
+
+func TestNewClient_WithRetryPolicyAndRateLimiter(t *testing.T) {
+	policy := notion.DefaultRetryPolicy()
+	limiter := notion.NewRateLimiter(3, 3)
+
+	client := notion.NewClient(nil, "0000", notion.WithRetryPolicy(policy), notion.WithRateLimiter(limiter))
+	assert.Equal(t, policy, client.RetryPolicy)
+	assert.Equal(t, limiter, client.RateLimiter)
+}
+
+func TestAuthClient_Options(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/stand-in/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assert.Equal(t, "my-integration/1.0", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testAccessTokenResJSON)
+	})
+
+	config := oauth2.Config{
+		ClientID:     "client_id",
+		ClientSecret: "client_sec",
+		RedirectURL:  "http://localhost:8081",
+	}
+
+	client := notion.AuthClient(http.DefaultClient,
+		notion.WithBaseURL(server.URL+"/stand-in/"),
+		notion.WithUserAgent("my-integration/1.0"),
+	)
+	resp, _, err := client.Auth.AccessToken(context.Background(), &config, "1234")
+	assert.Nil(t, err)
+	assert.Equal(t, &testAccessTokenRes, resp)
+}
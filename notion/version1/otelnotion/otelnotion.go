@@ -0,0 +1,202 @@
+// Package otelnotion instruments the calls a notion.Client makes with OpenTelemetry. It is a module of its own, so
+// only integrations that use it depend on OpenTelemetry.
+//
+// Middleware is added to a Client with notion.WithMiddleware:
+//
+//	client := notion.NewClient(http.DefaultClient, accessToken, notion.WithMiddleware(otelnotion.Middleware()))
+//
+// Every service call gets a client span named after its operation, e.g. "notion databases.query", and is measured
+// by these instruments:
+//
+//	notion.client.duration         how long calls take, retries and rate limit waits included, in seconds
+//	notion.client.retries          how many requests the Client's RetryPolicy repeated
+//	notion.client.rate_limit.wait  how long the Client's RateLimiter delayed calls, in seconds
+package otelnotion
+
+import (
+	"context"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"time"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter used by Middleware.
+const ScopeName = "github.com/oyekanmiayo/go-notion/notion/version1/otelnotion"
+
+// Attribute keys set on spans and measurements, besides the HTTP semantic conventions.
+const (
+	// OperationKey names the call, e.g. "databases.query".
+	OperationKey = attribute.Key("notion.operation")
+
+	// ObjectIDKey is the ID of the database, page, block or user the call is about.
+	ObjectIDKey = attribute.Key("notion.object_id")
+
+	// PageKey is the number of the page of results an iterator is fetching, starting at 1.
+	PageKey = attribute.Key("notion.pagination.page")
+
+	// ErrorCodeKey is the code of the error Notion answered with, e.g. "object_not_found".
+	ErrorCodeKey = attribute.Key("notion.error.code")
+
+	// RequestIDKey is the ID Notion gave the request, in its X-Notion-Request-Id header.
+	RequestIDKey = attribute.Key("notion.request_id")
+
+	// AttemptsKey is how many times the request was sent, retries included.
+	AttemptsKey = attribute.Key("notion.attempts")
+
+	// RateLimitWaitKey is how long the Client's RateLimiter delayed the call, in seconds.
+	RateLimitWaitKey = attribute.Key("notion.rate_limit.wait")
+)
+
+// Option configures Middleware.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider spans are created with. Defaults to the global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider instruments are created with. Defaults to the global one.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Middleware returns a notion.Middleware tracing and measuring every call made by a Client.
+func Middleware(opts ...Option) notion.Middleware {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName)
+	meter := c.meterProvider.Meter(ScopeName)
+	// Instruments that fail to be created are no-ops, and the error is reported to the global handler.
+	duration, err := meter.Float64Histogram("notion.client.duration",
+		metric.WithDescription("How long calls to the Notion API take, retries and rate limit waits included."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	retries, err := meter.Int64Counter("notion.client.retries",
+		metric.WithDescription("Requests to the Notion API repeated by the RetryPolicy."),
+		metric.WithUnit("{retry}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	waits, err := meter.Float64Histogram("notion.client.rate_limit.wait",
+		metric.WithDescription("How long the RateLimiter delayed calls to the Notion API."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next notion.Doer) notion.Doer {
+		return notion.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			call := notion.CallInfo(req.Context())
+			if call == nil {
+				call = &notion.Call{}
+			}
+
+			ctx, span := tracer.Start(req.Context(), spanName(call.Operation),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(requestAttributes(req, call)...))
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next.Do(req.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			attrs := []attribute.KeyValue{OperationKey.String(call.Operation)}
+			span.SetAttributes(
+				AttemptsKey.Int(call.Attempts),
+				RateLimitWaitKey.Float64(call.RateLimitWait.Seconds()),
+			)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, errorType(err))
+			default:
+				status := semconv.HTTPResponseStatusCode(resp.StatusCode)
+				span.SetAttributes(status)
+				attrs = append(attrs, status)
+				if id := resp.Header.Get("X-Notion-Request-Id"); id != "" {
+					span.SetAttributes(RequestIDKey.String(id))
+				}
+				if apiErr, ok := notion.ResponseError(resp).(notion.APIError); ok {
+					span.SetStatus(codes.Error, apiErr.Message)
+					if apiErr.Code != "" {
+						code := ErrorCodeKey.String(apiErr.Code)
+						span.SetAttributes(code)
+						attrs = append(attrs, code)
+					}
+				}
+			}
+
+			set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+			duration.Record(ctx, elapsed.Seconds(), set)
+			if call.Attempts > 1 {
+				retries.Add(ctx, int64(call.Attempts-1), set)
+			}
+			if call.RateLimitWait > 0 {
+				waits.Record(ctx, call.RateLimitWait.Seconds(),
+					metric.WithAttributes(OperationKey.String(call.Operation)))
+			}
+			return resp, err
+		})
+	}
+}
+
+// spanName names the span of a call after its operation.
+func spanName(operation string) string {
+	if operation == "" {
+		return "notion"
+	}
+	return "notion " + operation
+}
+
+// requestAttributes describes a call before it is sent.
+func requestAttributes(req *http.Request, call *notion.Call) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(call.Operation),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if call.ObjectID != "" {
+		attrs = append(attrs, ObjectIDKey.String(call.ObjectID))
+	}
+	if call.Page > 0 {
+		attrs = append(attrs, PageKey.Int(call.Page))
+	}
+	return attrs
+}
+
+// errorType classifies an error that prevented a response, as the error.type attribute.
+func errorType(err error) attribute.KeyValue {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return semconv.ErrorTypeKey.String("canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return semconv.ErrorTypeKey.String("timeout")
+	}
+	return semconv.ErrorTypeOther
+}
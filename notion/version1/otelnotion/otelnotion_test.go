@@ -0,0 +1,149 @@
+package otelnotion_test
+
+import (
+	"context"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/notiontest"
+	"github.com/oyekanmiayo/go-notion/notion/version1/otelnotion"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// instrumented returns a notiontest server with two users, and a client of it traced to spans and measured by reader.
+func instrumented(t *testing.T, opts ...notion.Option) (*notiontest.Server, *notion.Client,
+	*tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	server := notiontest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddUser(notion.User{Name: "Ada"})
+	server.AddUser(notion.User{Name: "Grace"})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	middleware := otelnotion.Middleware(
+		otelnotion.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		otelnotion.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	client := server.Client(append(opts, notion.WithMiddleware(middleware))...)
+	return server, client, spans, reader
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+// metrics returns the metrics collected by reader, by name.
+func metrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+	data := make(map[string]metricdata.Aggregation)
+	for _, scope := range rm.ScopeMetrics {
+		assert.Equal(t, otelnotion.ScopeName, scope.Scope.Name)
+		for _, m := range scope.Metrics {
+			data[m.Name] = m.Data
+		}
+	}
+	return data
+}
+
+func TestMiddleware_Pagination(t *testing.T) {
+	_, client, spans, reader := instrumented(t)
+
+	var users []string
+	it := client.Users.ListIter(context.Background(), &notion.ListUsersQueryParams{PageSize: 1})
+	for it.Next() {
+		users = append(users, it.Value().Name)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"Ada", "Grace"}, users)
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		for i, span := range ended {
+			assert.Equal(t, "notion users.list", span.Name())
+			assert.Equal(t, codes.Unset, span.Status().Code)
+			attrs := attributes(span)
+			assert.Equal(t, "users.list", attrs[otelnotion.OperationKey].AsString())
+			assert.Equal(t, int64(i+1), attrs[otelnotion.PageKey].AsInt64())
+			assert.Equal(t, int64(http.StatusOK), attrs["http.response.status_code"].AsInt64())
+			assert.Equal(t, http.MethodGet, attrs["http.request.method"].AsString())
+			assert.Equal(t, int64(1), attrs[otelnotion.AttemptsKey].AsInt64())
+		}
+	}
+
+	data := metrics(t, reader)
+	if duration, ok := data["notion.client.duration"].(metricdata.Histogram[float64]); assert.True(t, ok) {
+		if assert.Len(t, duration.DataPoints, 1) {
+			assert.Equal(t, uint64(2), duration.DataPoints[0].Count)
+		}
+	}
+	assert.NotContains(t, data, "notion.client.retries")
+	assert.NotContains(t, data, "notion.client.rate_limit.wait")
+}
+
+func TestMiddleware_RetriesAndErrors(t *testing.T) {
+	server, client, spans, reader := instrumented(t,
+		notion.WithRetryPolicy(&notion.RetryPolicy{MaxAttempts: 3}),
+		notion.WithRateLimiter(notion.NewRateLimiter(50, 1)))
+	db := server.AddDatabase(notion.Database{Properties: map[string]notion.PropertyObj{"Name": {Type: "title"}}})
+	ctx := context.Background()
+
+	server.Fail(notiontest.Fault{
+		Method:     http.MethodPost,
+		Path:       "databases/*/query",
+		Status:     http.StatusTooManyRequests,
+		RetryAfter: time.Millisecond,
+		Times:      2,
+	})
+	_, _, err := client.Databases.QueryDatabase(ctx, db.ID, nil)
+	assert.Nil(t, err)
+	_, _, err = client.Pages.RetrievePage(ctx, "unknown")
+	assert.True(t, notion.IsNotFound(err))
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		query := attributes(ended[0])
+		assert.Equal(t, "notion databases.query", ended[0].Name())
+		assert.Equal(t, db.ID, query[otelnotion.ObjectIDKey].AsString())
+		assert.Equal(t, int64(3), query[otelnotion.AttemptsKey].AsInt64())
+		assert.True(t, query[otelnotion.RateLimitWaitKey].AsFloat64() > 0)
+		assert.NotContains(t, query, otelnotion.PageKey)
+
+		page := attributes(ended[1])
+		assert.Equal(t, "unknown", page[otelnotion.ObjectIDKey].AsString())
+		assert.Equal(t, int64(http.StatusNotFound), page["http.response.status_code"].AsInt64())
+		assert.Equal(t, "object_not_found", page[otelnotion.ErrorCodeKey].AsString())
+		assert.Equal(t, codes.Error, ended[1].Status().Code)
+	}
+
+	data := metrics(t, reader)
+	if retries, ok := data["notion.client.retries"].(metricdata.Sum[int64]); assert.True(t, ok) {
+		if assert.Len(t, retries.DataPoints, 1) {
+			assert.Equal(t, int64(2), retries.DataPoints[0].Value)
+			operation, _ := retries.DataPoints[0].Attributes.Value(otelnotion.OperationKey)
+			assert.Equal(t, "databases.query", operation.AsString())
+		}
+	}
+	if waits, ok := data["notion.client.rate_limit.wait"].(metricdata.Histogram[float64]); assert.True(t, ok) {
+		assert.NotEmpty(t, waits.DataPoints)
+	}
+	if duration, ok := data["notion.client.duration"].(metricdata.Histogram[float64]); assert.True(t, ok) {
+		assert.Len(t, duration.DataPoints, 2)
+		for _, point := range duration.DataPoints {
+			if code, ok := point.Attributes.Value(otelnotion.ErrorCodeKey); ok {
+				assert.Equal(t, "object_not_found", code.AsString())
+			}
+		}
+	}
+}
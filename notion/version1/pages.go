@@ -1,8 +1,11 @@
 package version1
 
 import (
+	"context"
+	"encoding/json"
 	"github.com/dghubble/sling"
 	"net/http"
+	"strings"
 )
 
 type PageService struct {
@@ -22,24 +25,118 @@ func newPageService(sling *sling.Sling) *PageService {
 // - If parent.type is "page_id" or "workspace", then the only valid key is title.
 // - If parent.type is "database_id", then the keys and values of this field are determined by the properties
 //   of the database this page belongs to.
-// Page can be *DatabaseParent, *PageParent or *WorkspaceParent
 type Page struct {
 	Object         string                  `json:"object,omitempty"`
 	ID             string                  `json:"id,omitempty"`
 	CreatedTime    string                  `json:"created_time,omitempty"`
 	LastEditedTime string                  `json:"last_edited_time,omitempty"`
-	Parent         interface{}             `json:"parent,omitempty"`
+	Parent         *Parent                 `json:"parent,omitempty"`
 	Archived       bool                    `json:"archived,omitempty"`
 	Properties     map[string]PageProperty `json:"properties,omitempty"`
 }
 
+// Parent is the parent of a page. Type is one of "database_id", "page_id" or "workspace", and the matching
+// Database, Page or Workspace field is set.
+// Use NewDatabaseParent, NewPageParent or NewWorkspaceParent to create one.
+// https://developers.notion.com/reference/page#page-parent
+type Parent struct {
+	Type      string
+	Database  *DatabaseParent
+	Page      *PageParent
+	Workspace *WorkspaceParent
+}
+
+// NewDatabaseParent returns a Parent for a page that is an entry of the database with databaseID.
+func NewDatabaseParent(databaseID string) *Parent {
+	return &Parent{
+		Type:     "database_id",
+		Database: &DatabaseParent{Type: "database_id", DatabaseID: databaseID},
+	}
+}
+
+// NewPageParent returns a Parent for a page nested in the page with pageID.
+func NewPageParent(pageID string) *Parent {
+	return &Parent{
+		Type: "page_id",
+		Page: &PageParent{Type: "page_id", PageID: pageID},
+	}
+}
+
+// NewWorkspaceParent returns a Parent for a page at the top level of the workspace.
+func NewWorkspaceParent() *Parent {
+	return &Parent{
+		Type:      "workspace",
+		Workspace: &WorkspaceParent{Type: "workspace"},
+	}
+}
+
+// DatabaseID returns the ID of the parent database, if the parent is a database.
+func (p *Parent) DatabaseID() (string, bool) {
+	if p == nil || p.Database == nil {
+		return "", false
+	}
+	return p.Database.DatabaseID, true
+}
+
+// PageID returns the ID of the parent page, if the parent is a page.
+func (p *Parent) PageID() (string, bool) {
+	if p == nil || p.Page == nil {
+		return "", false
+	}
+	return p.Page.PageID, true
+}
+
+// IsWorkspace reports whether the parent is the workspace.
+func (p *Parent) IsWorkspace() bool {
+	return p != nil && p.Workspace != nil
+}
+
+func (p Parent) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.Database != nil:
+		return json.Marshal(p.Database)
+	case p.Page != nil:
+		return json.Marshal(p.Page)
+	case p.Workspace != nil:
+		return json.Marshal(p.Workspace)
+	}
+	return json.Marshal(struct {
+		Type string `json:"type,omitempty"`
+	}{p.Type})
+}
+
+// UnmarshalJSON decodes the parent into Database, Page or Workspace based on its type.
+// Parents of other types only have Type set.
+func (p *Parent) UnmarshalJSON(data []byte) error {
+	var parent struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &parent); err != nil {
+		return err
+	}
+
+	*p = Parent{Type: parent.Type}
+	switch parent.Type {
+	case "database_id":
+		p.Database = new(DatabaseParent)
+		return json.Unmarshal(data, p.Database)
+	case "page_id":
+		p.Page = new(PageParent)
+		return json.Unmarshal(data, p.Page)
+	case "workspace":
+		p.Workspace = new(WorkspaceParent)
+		return json.Unmarshal(data, p.Workspace)
+	}
+	return nil
+}
+
 // Type is always "database_id" for Database Parent
 type DatabaseParent struct {
 	Type       string `json:"type,omitempty"`
 	DatabaseID string `json:"database_id,omitempty"`
 }
 
-// Type is always "page_id
+// Type is always "page_id"
 type PageParent struct {
 	Type   string `json:"type,omitempty"`
 	PageID string `json:"page_id,omitempty"`
@@ -53,13 +150,14 @@ type WorkspaceParent struct {
 // Type can be only one of "rich_text", "number", "select", "multi_select", "date", "formula",
 // "relation", "rollup", "title", "people", "files", "checkbox", "url", "email", "phone_number",
 // "created_time", "created_by", "last_edited_time", and "last_edited_by".
+// Number is nil when the number is empty. Use Float64 to set it, including to 0.
 // https://developers.notion.com/reference/page#all-property-values
 type PageProperty struct {
 	ID             string                    `json:"id,omitempty"`
 	Type           string                    `json:"type,omitempty"`
 	Title          []RichText                `json:"title,omitempty"`
 	RichText       []RichText                `json:"rich_text,omitempty"`
-	Number         int64                     `json:"number,omitempty"`
+	Number         *float64                  `json:"number,omitempty"`
 	Select         *SelectProperty           `json:"select,omitempty"`
 	URL            string                    `json:"url,omitempty"`
 	Email          string                    `json:"email,omitempty"`
@@ -79,8 +177,92 @@ type PageProperty struct {
 	PhoneNumber    string                    `json:"phone_number,omitempty"`
 }
 
+// MarshalJSON sends the value of p's Type even when it is empty, so a property can be set to false or 0,
+// or cleared. Empty text, multi-select, people, files and relation values are sent as [], and empty numbers,
+// selects, dates, URLs, emails and phone numbers are sent as null.
+// Properties without a Type only send their non-empty fields.
+func (p PageProperty) MarshalJSON() ([]byte, error) {
+	type pageProperty PageProperty
+	return marshalWithFields(pageProperty(p), p.emptyValue()...)
+}
+
+// emptyValue returns the value of p's Type if it is empty and would otherwise be left out by omitempty.
+func (p PageProperty) emptyValue() []field {
+	switch p.Type {
+	case "title":
+		if len(p.Title) == 0 {
+			return []field{{p.Type, []RichText{}}}
+		}
+	case "rich_text":
+		if len(p.RichText) == 0 {
+			return []field{{p.Type, []RichText{}}}
+		}
+	case "number":
+		if p.Number == nil {
+			return []field{{p.Type, nil}}
+		}
+	case "select":
+		if p.Select == nil {
+			return []field{{p.Type, nil}}
+		}
+	case "multi_select":
+		if len(p.MultiSelect) == 0 {
+			return []field{{p.Type, []MultiSelectPropertyOpts{}}}
+		}
+	case "date":
+		if p.Date == nil {
+			return []field{{p.Type, nil}}
+		}
+	case "people":
+		if len(p.People) == 0 {
+			return []field{{p.Type, []User{}}}
+		}
+	case "files":
+		if len(p.Files) == 0 {
+			return []field{{p.Type, []FileReferenceProperty{}}}
+		}
+	case "relation":
+		if len(p.Relation) == 0 {
+			return []field{{p.Type, []PageReferenceProperty{}}}
+		}
+	case "checkbox":
+		if !p.Checkbox {
+			return []field{{p.Type, false}}
+		}
+	case "url":
+		if p.URL == "" {
+			return []field{{p.Type, nil}}
+		}
+	case "email":
+		if p.Email == "" {
+			return []field{{p.Type, nil}}
+		}
+	case "phone_number":
+		if p.PhoneNumber == "" {
+			return []field{{p.Type, nil}}
+		}
+	}
+	return nil
+}
+
+// ClearProperty returns a PageProperty that empties a property of the given type when passed to
+// UpdatePageProperties, e.g. ClearProperty("date") removes a date.
+func ClearProperty(propertyType string) PageProperty {
+	return PageProperty{Type: propertyType}
+}
+
+// Number is nil when the number is empty.
 type NumberProperty struct {
-	Number int32 `json:"number,omitempty"`
+	Number *float64 `json:"number,omitempty"`
+}
+
+func (p NumberProperty) MarshalJSON() ([]byte, error) {
+	type numberProperty NumberProperty
+	var fields []field
+	if p.Number == nil {
+		fields = append(fields, field{"number", nil})
+	}
+	return marshalWithFields(numberProperty(p), fields...)
 }
 
 // Color can only be "default", "gray", "brown", "red", "orange", "yellow", "green",
@@ -111,16 +293,26 @@ type DateProperty struct {
 }
 
 // Type must be one of "string", "number", "boolean", and "date".
+// Number is nil when a number formula has no result.
 // https://developers.notion.com/reference/page#formula-property-values
 // Use *Date for Date
 type FormulaProperty struct {
 	Type    string        `json:"type"`
 	String  string        `json:"string,omitempty"`
-	Number  int32         `json:"number,omitempty"`
+	Number  *float64      `json:"number,omitempty"`
 	Boolean bool          `json:"boolean,omitempty"`
 	Date    *DateProperty `json:"date,omitempty"`
 }
 
+func (p FormulaProperty) MarshalJSON() ([]byte, error) {
+	type formulaProperty FormulaProperty
+	var fields []field
+	if p.Type == "number" && p.Number == nil {
+		fields = append(fields, field{"number", nil})
+	}
+	return marshalWithFields(formulaProperty(p), fields...)
+}
+
 // https://developers.notion.com/reference/page#relation-property-values
 type PageReferenceProperty struct {
 	ID string `json:"id,omitempty"`
@@ -131,11 +323,20 @@ type PageReferenceProperty struct {
 // Use  for Array
 type RollupProperty struct {
 	Type   string                  `json:"type,omitempty"`
-	Number int32                   `json:"number,omitempty"`
+	Number *float64                `json:"number,omitempty"`
 	Date   *DateProperty           `json:"date,omitempty"`
 	Array  []RollupPropertyElement `json:"array,omitempty"`
 }
 
+func (p RollupProperty) MarshalJSON() ([]byte, error) {
+	type rollupProperty RollupProperty
+	var fields []field
+	if p.Type == "number" && p.Number == nil {
+		fields = append(fields, field{"number", nil})
+	}
+	return marshalWithFields(rollupProperty(p), fields...)
+}
+
 // https://developers.notion.com/reference/page#rollup-property-value-element
 // Type is one of rich_text", "number", "select", "multi_select", "date", "formula", "relation",
 // "rollup", "title", "people", "files", "checkbox", "url", "email", "phone_number", "created_time",
@@ -144,7 +345,7 @@ type RollupPropertyElement struct {
 	Type           string                    `json:"type"`
 	Title          []RichText                `json:"title,omitempty"`
 	RichText       []RichText                `json:"rich_text,omitempty"`
-	Number         int64                     `json:"number,omitempty"`
+	Number         *float64                  `json:"number,omitempty"`
 	Select         *SelectProperty           `json:"select,omitempty"`
 	URL            string                    `json:"url,omitempty"`
 	Email          string                    `json:"email,omitempty"`
@@ -164,47 +365,61 @@ type RollupPropertyElement struct {
 	PhoneNumber    string                    `json:"phone_number,omitempty"`
 }
 
+func (p RollupPropertyElement) MarshalJSON() ([]byte, error) {
+	type rollupPropertyElement RollupPropertyElement
+	var fields []field
+	if p.Type == "number" && p.Number == nil {
+		fields = append(fields, field{"number", nil})
+	}
+	return marshalWithFields(rollupPropertyElement(p), fields...)
+}
+
 // https://developers.notion.com/reference/page#files-property-values
 type FileReferenceProperty struct {
 	Name string `json:"name,omitempty"`
 }
 
 // https://developers.notion.com/reference/get-page
-func (p *PageService) RetrievePage(pageID string) (*Page, *http.Response, error) {
+func (p *PageService) RetrievePage(ctx context.Context, pageID string) (*Page, *http.Response, error) {
 	page := new(Page)
-	apiError := new(APIError)
-	resp, err := p.sling.New().Get(pageID).Receive(page, apiError)
+	resp, err := receive(ctx, idempotent.named("pages.retrieve").about(pageID), p.sling.New().Get(pageID), page)
 
-	return page, resp, relevantError(err, *apiError)
+	return page, resp, err
 }
 
-// Use *DatabaseParent or *PageParent for Parent
+// Use NewDatabaseParent or NewPageParent for Parent
 // Read https://developers.notion.com/reference/post-page before using this
 type CreatePageBodyParams struct {
-	Parent     interface{} `json:"parent,omitempty"`
+	Parent     *Parent     `json:"parent,omitempty"`
 	Properties interface{} `json:"properties,omitempty"`
 	Children   []Block     `json:"children,omitempty"`
 }
 
 // https://developers.notion.com/reference/post-page
-func (p *PageService) CreatePage(params *CreatePageBodyParams) (*Page, *http.Response, error) {
+func (p *PageService) CreatePage(ctx context.Context, params *CreatePageBodyParams) (*Page, *http.Response, error) {
 	page := new(Page)
-	apiError := new(APIError)
-	resp, err := p.sling.New().Post("").BodyJSON(params).Receive(page, apiError)
+	resp, err := receive(ctx, nonIdempotent.named("pages.create"), p.sling.New().Post("").BodyJSON(params), page)
 
-	return page, resp, relevantError(err, *apiError)
+	return page, resp, err
 }
 
+// Properties that are left out of the map are not changed.
+// Set Type on a PageProperty to send its value even when it is empty, e.g. to uncheck a checkbox or clear a date.
 type UpdatePagePropertiesBodyParams struct {
 	Properties map[string]PageProperty `json:"properties,omitempty"`
 }
 
 // https://developers.notion.com/reference/patch-page
-func (p *PageService) UpdatePageProperties(pageID string,
+func (p *PageService) UpdatePageProperties(ctx context.Context, pageID string,
 	params *UpdatePagePropertiesBodyParams) (*Page, *http.Response, error) {
 	page := new(Page)
-	apiError := new(APIError)
-	resp, err := p.sling.New().Patch(pageID).BodyJSON(params).Receive(page, apiError)
+	resp, err := receive(ctx, idempotent.named("pages.update").about(pageID),
+		p.sling.New().Patch(pageID).BodyJSON(params), page)
+
+	return page, resp, err
+}
 
-	return page, resp, relevantError(err, *apiError)
+// PageURL returns the notion.so URL of the page or database with id.
+func PageURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
 }
@@ -1,6 +1,8 @@
 package version1_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
@@ -16,12 +18,7 @@ var (
 		ID:             "5678",
 		CreatedTime:    "2021-05-14T01:06:32.845Z",
 		LastEditedTime: "2021-05-23T08:02:00.000Z",
-		// Technically this is &DatabaseParent but Go will see type as map[string]interface{}
-		// Haven't found a workaround yet :)
-		Parent: map[string]interface{}{
-			"database_id": "38923",
-			"type":        "database_id",
-		},
+		Parent:         notion.NewDatabaseParent("38923"),
 		Properties: map[string]notion.PageProperty{
 			"Name": {
 				ID:   "title",
@@ -77,8 +74,10 @@ var (
 
 	testCreatePageBodyJSON = `{"parent":{"database_id":"7d6410f1-0c2d-4c75-8199-3fd7d90ff4ff"},"properties":{"Name":{"title":[{"text":{"content":"Jamboree"}}]},"Recommended":{"checkbox":true},"Tags":{"multi_select":[{"name":"TagTest"}]}}}` + "\n"
 	testCreatePageBody     = &notion.CreatePageBodyParams{
-		Parent: &notion.DatabaseParent{
-			DatabaseID: "7d6410f1-0c2d-4c75-8199-3fd7d90ff4ff",
+		Parent: &notion.Parent{
+			Database: &notion.DatabaseParent{
+				DatabaseID: "7d6410f1-0c2d-4c75-8199-3fd7d90ff4ff",
+			},
 		},
 		Properties: testPageProperty,
 	}
@@ -104,7 +103,7 @@ func TestPageService_RetrievePage(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Pages.RetrievePage("123")
+	resp, _, err := client.Pages.RetrievePage(context.Background(), "123")
 	assert.Nil(t, err)
 	assert.Equal(t, testRetrievePageRes, resp)
 }
@@ -121,7 +120,7 @@ func TestPageService_CreatePage(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Pages.CreatePage(testCreatePageBody)
+	resp, _, err := client.Pages.CreatePage(context.Background(), testCreatePageBody)
 	assert.Nil(t, err)
 	assert.Equal(t, testCreatePageRes, resp)
 }
@@ -138,7 +137,114 @@ func TestPageService_UpdatePageProperties(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Pages.UpdatePageProperties("123", testUpdatePropertiesBody)
+	resp, _, err := client.Pages.UpdatePageProperties(context.Background(), "123", testUpdatePropertiesBody)
 	assert.Nil(t, err)
 	assert.Equal(t, testUpdatePropertiesRes, resp)
 }
+
+func TestParent_JSON(t *testing.T) {
+	cases := []struct {
+		json   string
+		parent *notion.Parent
+	}{
+		{`{"type":"database_id","database_id":"123"}`, notion.NewDatabaseParent("123")},
+		{`{"type":"page_id","page_id":"456"}`, notion.NewPageParent("456")},
+		{`{"type":"workspace"}`, notion.NewWorkspaceParent()},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.parent)
+		assert.Nil(t, err)
+		assert.Equal(t, c.json, string(data))
+
+		parent := new(notion.Parent)
+		assert.Nil(t, json.Unmarshal([]byte(c.json), parent))
+		assert.Equal(t, c.parent, parent)
+	}
+}
+
+func TestParent_Accessors(t *testing.T) {
+	databaseID, ok := notion.NewDatabaseParent("123").DatabaseID()
+	assert.True(t, ok)
+	assert.Equal(t, "123", databaseID)
+
+	_, ok = notion.NewDatabaseParent("123").PageID()
+	assert.False(t, ok)
+
+	pageID, ok := notion.NewPageParent("456").PageID()
+	assert.True(t, ok)
+	assert.Equal(t, "456", pageID)
+
+	assert.True(t, notion.NewWorkspaceParent().IsWorkspace())
+	assert.False(t, notion.NewPageParent("456").IsWorkspace())
+}
+
+func TestPageProperty_Number(t *testing.T) {
+	cases := []struct {
+		json     string
+		property notion.PageProperty
+	}{
+		{`{"id":"a","type":"number","number":3.14}`, notion.PageProperty{ID: "a", Type: "number", Number: notion.Float64(3.14)}},
+		{`{"id":"a","type":"number","number":0}`, notion.PageProperty{ID: "a", Type: "number", Number: notion.Float64(0)}},
+		{`{"id":"a","type":"number","number":null}`, notion.PageProperty{ID: "a", Type: "number"}},
+		{`{"id":"b","type":"formula","formula":{"type":"number","number":-2.5}}`, notion.PageProperty{
+			ID:      "b",
+			Type:    "formula",
+			Formula: &notion.FormulaProperty{Type: "number", Number: notion.Float64(-2.5)},
+		}},
+		{`{"id":"c","type":"rollup","rollup":{"type":"number","number":null}}`, notion.PageProperty{
+			ID:     "c",
+			Type:   "rollup",
+			Rollup: &notion.RollupProperty{Type: "number"},
+		}},
+	}
+
+	for _, c := range cases {
+		property := notion.PageProperty{}
+		assert.Nil(t, json.Unmarshal([]byte(c.json), &property))
+		assert.Equal(t, c.property, property)
+
+		data, err := json.Marshal(c.property)
+		assert.Nil(t, err)
+		assert.Equal(t, c.json, string(data))
+	}
+}
+
+func TestPageService_UpdatePageProperties_EmptyValues(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/pages/123", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "PATCH", r)
+		assertPostJSON(t, `{"properties":{`+
+			`"Done":{"type":"checkbox","checkbox":false},`+
+			`"Due":{"type":"date","date":null},`+
+			`"Name":{"title":[{"text":{"content":"Jamboree"}}]},`+
+			`"Notes":{"type":"rich_text","rich_text":[]},`+
+			`"Price":{"type":"number","number":0},`+
+			`"Status":{"type":"select","select":null},`+
+			`"Website":{"type":"url","url":null}}}`+"\n", r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUpdatePropertiesResJSON)
+	})
+
+	params := &notion.UpdatePagePropertiesBodyParams{
+		Properties: map[string]notion.PageProperty{
+			"Done":    {Type: "checkbox", Checkbox: false},
+			"Due":     notion.ClearProperty("date"),
+			"Name":    testPageProperty["Name"],
+			"Notes":   notion.ClearProperty("rich_text"),
+			"Price":   {Type: "number", Number: notion.Float64(0)},
+			"Status":  notion.ClearProperty("select"),
+			"Website": notion.ClearProperty("url"),
+		},
+	}
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Pages.UpdatePageProperties(context.Background(), "123", params)
+	assert.Nil(t, err)
+}
+
+func TestPageURL(t *testing.T) {
+	assert.Equal(t, "https://www.notion.so/1234abcd", notion.PageURL("1234-abcd"))
+}
@@ -0,0 +1,187 @@
+// Package plaintext extracts the plain text of Notion blocks, e.g. for search indexing, and computes statistics
+// about them.
+//
+// It works on the blocks returned by BlockService.RetrieveBlockTree, so it includes the text of nested blocks:
+//
+//	blocks, err := client.Blocks.RetrieveBlockTree(ctx, pageID, nil)
+//	if err != nil {
+//		// handle err
+//	}
+//	text := plaintext.Text(blocks)
+//	stats := plaintext.Analyze(blocks)
+package plaintext
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"strings"
+	"unicode"
+)
+
+// Extractor extracts plain text from blocks. Its fields are written between and around the text of blocks; the
+// zero value writes none of them, so start from a copy of Default to change some.
+type Extractor struct {
+	// BlockSeparator separates blocks.
+	BlockSeparator string
+
+	// Heading is written after headings, on top of BlockSeparator.
+	Heading string
+
+	// ListItem is written before the text of bulleted and numbered list items.
+	ListItem string
+
+	// ToDo and Done are written before the text of unchecked and checked to-dos.
+	ToDo string
+	Done string
+
+	// Indent is written before each line of the children of a block, once per level of nesting.
+	Indent string
+
+	// TableCell separates the cells of table rows.
+	TableCell string
+}
+
+// Default is the Extractor used by Text. It writes one block per line, a blank line after headings, list items as
+// "- item", to-dos as "[ ] to-do" and "[x] to-do", children indented by two spaces and table cells separated by
+// tabs.
+var Default = Extractor{
+	BlockSeparator: "\n",
+	Heading:        "\n",
+	ListItem:       "- ",
+	ToDo:           "[ ] ",
+	Done:           "[x] ",
+	Indent:         "  ",
+	TableCell:      "\t",
+}
+
+// Text returns the plain text of blocks using Default.
+func Text(blocks []notion.Block) string {
+	return Default.Text(blocks)
+}
+
+// Text returns the plain text of blocks. Whitespace in text is collapsed to single spaces, except in code, and
+// blocks without text are left out.
+func (e *Extractor) Text(blocks []notion.Block) string {
+	var b strings.Builder
+	e.blocks(&b, blocks, "")
+	return b.String()
+}
+
+func (e *Extractor) blocks(b *strings.Builder, blocks []notion.Block, indent string) {
+	for i := range blocks {
+		block := &blocks[i]
+		text := e.block(block)
+		if text != "" {
+			if b.Len() > 0 {
+				b.WriteString(e.BlockSeparator)
+			}
+			b.WriteString(indent + strings.ReplaceAll(text, "\n", "\n"+indent))
+			if block.HeadingOne != nil || block.HeadingTwo != nil || block.HeadingThree != nil {
+				b.WriteString(e.Heading)
+			}
+		}
+		// Children of blocks without text, e.g. columns, aren't nested any deeper.
+		childIndent := indent
+		if text != "" {
+			childIndent += e.Indent
+		}
+		e.blocks(b, block.Children(), childIndent)
+	}
+}
+
+// block returns the text of block, without its children.
+func (e *Extractor) block(block *notion.Block) string {
+	switch {
+	case block.BulletedListItem != nil:
+		return e.prefix(e.ListItem, block.BulletedListItem.Text)
+	case block.NumberedListItem != nil:
+		return e.prefix(e.ListItem, block.NumberedListItem.Text)
+	case block.ToDo != nil && block.ToDo.Checked:
+		return e.prefix(e.Done, block.ToDo.Text)
+	case block.ToDo != nil:
+		return e.prefix(e.ToDo, block.ToDo.Text)
+	case block.Code != nil:
+		return strings.TrimRightFunc(notion.PlainText(block.Code.Text), unicode.IsSpace)
+	case block.Table != nil:
+		var rows []string
+		for _, row := range block.Table.Children {
+			if row.TableRow == nil {
+				continue
+			}
+			cells := make([]string, len(row.TableRow.Cells))
+			for i, cell := range row.TableRow.Cells {
+				cells[i] = normalize(notion.PlainText(cell))
+			}
+			rows = append(rows, strings.Join(cells, e.TableCell))
+		}
+		return strings.Join(rows, "\n")
+	}
+	return normalize(blockText(block))
+}
+
+func (e *Extractor) prefix(prefix string, richText []notion.RichText) string {
+	text := normalize(notion.PlainText(richText))
+	if text == "" {
+		return ""
+	}
+	return prefix + text
+}
+
+// blockText returns the plain text of blocks that have text, a caption or a title.
+func blockText(block *notion.Block) string {
+	switch {
+	case block.Paragraph != nil:
+		return notion.PlainText(block.Paragraph.Text)
+	case block.HeadingOne != nil:
+		return notion.PlainText(block.HeadingOne.Text)
+	case block.HeadingTwo != nil:
+		return notion.PlainText(block.HeadingTwo.Text)
+	case block.HeadingThree != nil:
+		return notion.PlainText(block.HeadingThree.Text)
+	case block.BulletedListItem != nil:
+		return notion.PlainText(block.BulletedListItem.Text)
+	case block.NumberedListItem != nil:
+		return notion.PlainText(block.NumberedListItem.Text)
+	case block.ToDo != nil:
+		return notion.PlainText(block.ToDo.Text)
+	case block.Toggle != nil:
+		return notion.PlainText(block.Toggle.Text)
+	case block.Quote != nil:
+		return notion.PlainText(block.Quote.Text)
+	case block.Callout != nil:
+		return notion.PlainText(block.Callout.Text)
+	case block.Template != nil:
+		return notion.PlainText(block.Template.Text)
+	case block.Code != nil:
+		return notion.PlainText(block.Code.Text)
+	case block.Equation != nil:
+		return block.Equation.Expression
+	case block.ChildPage != nil:
+		return block.ChildPage.Title
+	case block.ChildDatabase != nil:
+		return block.ChildDatabase.Title
+	case block.Image != nil:
+		return notion.PlainText(block.Image.Caption)
+	case block.Video != nil:
+		return notion.PlainText(block.Video.Caption)
+	case block.File != nil:
+		return notion.PlainText(block.File.Caption)
+	case block.PDF != nil:
+		return notion.PlainText(block.PDF.Caption)
+	case block.Bookmark != nil:
+		return notion.PlainText(block.Bookmark.Caption)
+	case block.Embed != nil:
+		return notion.PlainText(block.Embed.Caption)
+	}
+	return ""
+}
+
+// normalize collapses runs of whitespace within each line of s to single spaces, trims lines and drops empty ones.
+func normalize(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,75 @@
+package plaintext_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/plaintext"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func text(content string) []notion.RichText {
+	return []notion.RichText{{Type: "text", Text: &notion.Text{Content: content}}}
+}
+
+var testBlocks = []notion.Block{
+	{Type: "heading_1", HeadingOne: &notion.HeadingOneBlock{Text: text("Launch  plan")}},
+	{Type: "paragraph", Paragraph: &notion.ParagraphBlock{Text: []notion.RichText{
+		{Type: "text", Text: &notion.Text{Content: "Ship by "}},
+		{Type: "mention", PlainText: "@Ada", Mention: &notion.Mention{Type: "user", UserMention: &notion.User{ID: "u1"}}},
+		{Type: "text", Text: &notion.Text{Content: " soon.\n\n  Really."}},
+	}}},
+	{Type: "heading_2", HeadingTwo: &notion.HeadingTwoBlock{Text: text("Tasks")}},
+	{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Write docs"), Checked: true, Children: []notion.Block{
+		{Type: "bulleted_list_item", BulletedListItem: &notion.BulletedListItemBlock{Text: text("API reference")}},
+	}}},
+	{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Release")}},
+	{Type: "to_do", ToDo: &notion.ToDoBlock{Text: text("Announce")}},
+	{Type: "divider", Divider: &notion.DividerBlock{}},
+	{Type: "column_list", ColumnList: &notion.ColumnListBlock{Children: []notion.Block{
+		{Type: "column", Column: &notion.ColumnBlock{Children: []notion.Block{
+			{Type: "code", Code: &notion.CodeBlock{Text: text("if x {\n\treturn\n}\n"), Language: "go"}},
+		}}},
+	}}},
+	{Type: "table", Table: &notion.TableBlock{TableWidth: 2, Children: []notion.Block{
+		{Type: "table_row", TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{text("a"), text("b")}}},
+	}}},
+	{Type: "heading_3", HeadingThree: &notion.HeadingThreeBlock{Text: text("Notes")}},
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "Launch plan\n\n"+
+		"Ship by @Ada soon.\nReally.\n"+
+		"Tasks\n\n"+
+		"[x] Write docs\n"+
+		"  - API reference\n"+
+		"[ ] Release\n"+
+		"[ ] Announce\n"+
+		"if x {\n\treturn\n}\n"+
+		"a\tb\n"+
+		"Notes\n", plaintext.Text(testBlocks))
+}
+
+func TestExtractor_Separators(t *testing.T) {
+	e := plaintext.Default
+	e.BlockSeparator, e.Heading, e.ListItem, e.ToDo, e.Done, e.Indent = " | ", "", "* ", "TODO ", "DONE ", ""
+
+	assert.Equal(t, "Tasks | DONE Write docs | * API reference | TODO Release",
+		e.Text(testBlocks[2:5]))
+}
+
+func TestAnalyze(t *testing.T) {
+	stats := plaintext.Analyze(testBlocks)
+
+	assert.Equal(t, 22, stats.Words)
+	assert.Equal(t, []plaintext.Heading{
+		{Level: 1, Text: "Launch plan"},
+		{Level: 2, Text: "Tasks"},
+		{Level: 3, Text: "Notes"},
+	}, stats.Headings)
+	assert.Equal(t, 3, stats.ToDos)
+	assert.Equal(t, 1, stats.Done)
+	assert.InDelta(t, 1.0/3, stats.Completion(), 1e-9)
+
+	empty := plaintext.Analyze(nil)
+	assert.Equal(t, 0.0, empty.Completion())
+}
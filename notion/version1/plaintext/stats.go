@@ -0,0 +1,68 @@
+package plaintext
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"strings"
+)
+
+// Stats are statistics about the text of blocks.
+type Stats struct {
+	// Words is the number of words in the text of the blocks, separated by whitespace.
+	Words int
+
+	// Headings is the outline of the blocks' headings, in order.
+	Headings []Heading
+
+	// ToDos is the number of to-dos, and Done the number of those that are checked.
+	ToDos int
+	Done  int
+}
+
+// Heading is a heading in an outline.
+type Heading struct {
+	// Level is 1, 2 or 3, for heading_1, heading_2 and heading_3 blocks.
+	Level int
+	Text  string
+}
+
+// Completion returns the ratio of checked to-dos, from 0 to 1, or 0 if there are no to-dos.
+func (s *Stats) Completion() float64 {
+	if s.ToDos == 0 {
+		return 0
+	}
+	return float64(s.Done) / float64(s.ToDos)
+}
+
+// Analyze returns statistics about blocks and their children.
+func Analyze(blocks []notion.Block) Stats {
+	var stats Stats
+	stats.add(blocks)
+	return stats
+}
+
+func (s *Stats) add(blocks []notion.Block) {
+	for i := range blocks {
+		block := &blocks[i]
+		text := blockText(block)
+		if block.Table != nil {
+			text = Default.block(block)
+		}
+		s.Words += len(strings.Fields(text))
+
+		switch {
+		case block.HeadingOne != nil:
+			s.Headings = append(s.Headings, Heading{Level: 1, Text: normalize(text)})
+		case block.HeadingTwo != nil:
+			s.Headings = append(s.Headings, Heading{Level: 2, Text: normalize(text)})
+		case block.HeadingThree != nil:
+			s.Headings = append(s.Headings, Heading{Level: 3, Text: normalize(text)})
+		}
+		if block.ToDo != nil {
+			s.ToDos++
+			if block.ToDo.Checked {
+				s.Done++
+			}
+		}
+		s.add(block.Children())
+	}
+}
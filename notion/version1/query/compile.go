@@ -0,0 +1,316 @@
+package query
+
+import (
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/filter"
+)
+
+var (
+	textOperators = []string{"=", "!=", "contains", "not contains", "starts with", "ends with", "is empty",
+		"is not empty"}
+	numberOperators = []string{"=", "!=", ">", ">=", "<", "<=", "is empty", "is not empty"}
+	boolOperators   = []string{"=", "!="}
+	dateOperators   = append([]string{"=", ">", ">=", "<", "<=", "is empty", "is not empty"}, dateKeywords...)
+	listOperators   = []string{"contains", "not contains", "is empty", "is not empty"}
+)
+
+type compiler struct {
+	src string
+	db  *notion.Database
+}
+
+func (c *compiler) errorf(pos int, format string, args ...interface{}) *Error {
+	return newError(c.src, pos, format, args...)
+}
+
+func (c *compiler) compile(n node) (*filter.Filter, error) {
+	switch n := n.(type) {
+	case *logical:
+		filters := make([]*filter.Filter, len(n.operands))
+		for i, operand := range n.operands {
+			f, err := c.compile(operand)
+			if err != nil {
+				return nil, err
+			}
+			filters[i] = f
+		}
+		if n.op == "or" {
+			return filter.Or(filters...), nil
+		}
+		return filter.And(filters...), nil
+	case *comparison:
+		return c.comparison(n)
+	}
+	return nil, fmt.Errorf("query: unexpected node %T", n)
+}
+
+func (c *compiler) comparison(cmp *comparison) (*filter.Filter, error) {
+	obj, ok := c.db.Properties[cmp.property]
+	if !ok {
+		return nil, c.errorf(cmp.at, "unknown property %q", cmp.property)
+	}
+
+	name := cmp.property
+	switch obj.Type {
+	case "title":
+		return c.text(filter.Title(name), obj.Type, cmp)
+	case "rich_text", "text":
+		return c.text(filter.Text(name), obj.Type, cmp)
+	case "url":
+		return c.text(filter.URL(name), obj.Type, cmp)
+	case "email":
+		return c.text(filter.Email(name), obj.Type, cmp)
+	case "phone_number":
+		return c.text(filter.Phone(name), obj.Type, cmp)
+	case "number":
+		return c.number(filter.Number(name), obj.Type, cmp)
+	case "checkbox":
+		return c.checkbox(filter.Checkbox(name), obj.Type, cmp)
+	case "select":
+		return c.selectOption(filter.Select(name), obj.Type, cmp)
+	case "multi_select":
+		return c.multiSelect(filter.MultiSelect(name), obj.Type, cmp)
+	case "date":
+		return c.date(filter.Date(name), obj.Type, cmp)
+	case "created_time":
+		return c.date(filter.CreatedTime(name), obj.Type, cmp)
+	case "last_edited_time":
+		return c.date(filter.LastEditedTime(name), obj.Type, cmp)
+	case "people":
+		return c.people(filter.People(name), obj.Type, cmp)
+	case "created_by":
+		return c.people(filter.CreatedBy(name), obj.Type, cmp)
+	case "last_edited_by":
+		return c.people(filter.LastEditedBy(name), obj.Type, cmp)
+	case "file", "files":
+		return c.files(filter.Files(name), obj.Type, cmp)
+	case "relation":
+		return c.relation(filter.Relation(name), obj.Type, cmp)
+	case "formula":
+		return c.formula(filter.Formula(name), cmp)
+	}
+	return nil, c.errorf(cmp.at, "property %q of type %v cannot be filtered", cmp.property, obj.Type)
+}
+
+// check reports whether cmp uses one of operators and, if its operator takes a value, whether that value is of
+// kind.
+func (c *compiler) check(cmp *comparison, propertyType string, kind valueKind, operators []string) error {
+	if !contains(operators, cmp.op) {
+		return c.errorf(cmp.opAt, "%q cannot be used on %v property %q", cmp.op, propertyType, cmp.property)
+	}
+	if cmp.value != nil && cmp.value.kind != kind {
+		return c.errorf(cmp.value.at, "%v property %q must be compared to a %v, found %v %v", propertyType,
+			cmp.property, kind, cmp.value.kind, cmp.value.raw)
+	}
+	// Conditions omit empty strings, so the filter would be sent without a value.
+	if cmp.value != nil && cmp.value.kind == stringValue && cmp.value.text == "" {
+		return c.errorf(cmp.value.at, "%v property %q cannot be compared to an empty string; use \"is empty\" "+
+			"or \"is not empty\"", propertyType, cmp.property)
+	}
+	return nil
+}
+
+func (c *compiler) text(t filter.TextFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, textOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "=":
+		return t.Equals(cmp.value.text), nil
+	case "!=":
+		return t.DoesNotEqual(cmp.value.text), nil
+	case "contains":
+		return t.Contains(cmp.value.text), nil
+	case "not contains":
+		return t.DoesNotContain(cmp.value.text), nil
+	case "starts with":
+		return t.StartsWith(cmp.value.text), nil
+	case "ends with":
+		return t.EndsWith(cmp.value.text), nil
+	case "is empty":
+		return t.IsEmpty(), nil
+	}
+	return t.IsNotEmpty(), nil
+}
+
+func (c *compiler) number(n filter.NumberFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, numberValue, numberOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "=":
+		return n.Equals(cmp.value.number), nil
+	case "!=":
+		return n.DoesNotEqual(cmp.value.number), nil
+	case ">":
+		return n.GreaterThan(cmp.value.number), nil
+	case ">=":
+		return n.GreaterThanOrEqualTo(cmp.value.number), nil
+	case "<":
+		return n.LessThan(cmp.value.number), nil
+	case "<=":
+		return n.LessThanOrEqualTo(cmp.value.number), nil
+	case "is empty":
+		return n.IsEmpty(), nil
+	}
+	return n.IsNotEmpty(), nil
+}
+
+func (c *compiler) checkbox(b filter.CheckboxFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, boolValue, boolOperators); err != nil {
+		return nil, err
+	}
+
+	if cmp.op == "=" {
+		return b.Equals(cmp.value.boolean), nil
+	}
+	return b.DoesNotEqual(cmp.value.boolean), nil
+}
+
+func (c *compiler) selectOption(s filter.SelectFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, []string{"=", "!=", "is empty", "is not empty"}); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "=":
+		return s.Equals(cmp.value.text), nil
+	case "!=":
+		return s.DoesNotEqual(cmp.value.text), nil
+	case "is empty":
+		return s.IsEmpty(), nil
+	}
+	return s.IsNotEmpty(), nil
+}
+
+func (c *compiler) multiSelect(m filter.MultiSelectFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, listOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "contains":
+		return m.Contains(cmp.value.text), nil
+	case "not contains":
+		return m.DoesNotContain(cmp.value.text), nil
+	case "is empty":
+		return m.IsEmpty(), nil
+	}
+	return m.IsNotEmpty(), nil
+}
+
+func (c *compiler) date(d filter.DateFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, dateOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "=":
+		return d.Equals(cmp.value.text), nil
+	case ">":
+		return d.After(cmp.value.text), nil
+	case ">=":
+		return d.OnOrAfter(cmp.value.text), nil
+	case "<":
+		return d.Before(cmp.value.text), nil
+	case "<=":
+		return d.OnOrBefore(cmp.value.text), nil
+	case "is empty":
+		return d.IsEmpty(), nil
+	case "is not empty":
+		return d.IsNotEmpty(), nil
+	case "past_week":
+		return d.PastWeek(), nil
+	case "past_month":
+		return d.PastMonth(), nil
+	case "past_year":
+		return d.PastYear(), nil
+	case "next_week":
+		return d.NextWeek(), nil
+	case "next_month":
+		return d.NextMonth(), nil
+	}
+	return d.NextYear(), nil
+}
+
+func (c *compiler) people(p filter.PeopleFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, listOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "contains":
+		return p.Contains(cmp.value.text), nil
+	case "not contains":
+		return p.DoesNotContain(cmp.value.text), nil
+	case "is empty":
+		return p.IsEmpty(), nil
+	}
+	return p.IsNotEmpty(), nil
+}
+
+func (c *compiler) files(f filter.FilesFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, []string{"is empty", "is not empty"}); err != nil {
+		return nil, err
+	}
+
+	if cmp.op == "is empty" {
+		return f.IsEmpty(), nil
+	}
+	return f.IsNotEmpty(), nil
+}
+
+func (c *compiler) relation(r filter.RelationFilter, propertyType string, cmp *comparison) (*filter.Filter, error) {
+	if err := c.check(cmp, propertyType, stringValue, listOperators); err != nil {
+		return nil, err
+	}
+
+	switch cmp.op {
+	case "contains":
+		return r.Contains(cmp.value.text), nil
+	case "not contains":
+		return r.DoesNotContain(cmp.value.text), nil
+	case "is empty":
+		return r.IsEmpty(), nil
+	}
+	return r.IsNotEmpty(), nil
+}
+
+// formula picks the condition from what cmp compares to, since the schema doesn't say what a formula returns:
+// numbers and booleans compare to number and checkbox formulas, relative dates and ordered comparisons of
+// strings to date formulas, and everything else to text formulas.
+func (c *compiler) formula(f filter.FormulaFilter, cmp *comparison) (*filter.Filter, error) {
+	switch {
+	case cmp.value != nil && cmp.value.kind == numberValue:
+		return c.number(f.Number(), "formula", cmp)
+	case cmp.value != nil && cmp.value.kind == boolValue:
+		return c.checkbox(f.Checkbox(), "formula", cmp)
+	case contains(dateKeywords, cmp.op), contains([]string{">", ">=", "<", "<="}, cmp.op):
+		return c.date(f.Date(), "formula", cmp)
+	}
+	return c.text(f.Text(), "formula", cmp)
+}
+
+// sort turns key into a sort on its property or, if the database has no such property, on the created_time or
+// last_edited_time timestamp.
+func (c *compiler) sort(key sortKey) (notion.SortCriteria, error) {
+	if _, ok := c.db.Properties[key.property]; ok {
+		return notion.SortCriteria{Property: key.property, Direction: key.direction}, nil
+	}
+	if key.property == "created_time" || key.property == "last_edited_time" {
+		return notion.SortCriteria{Timestamp: key.property, Direction: key.direction}, nil
+	}
+	return notion.SortCriteria{}, c.errorf(key.at, "unknown property %q", key.property)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	// text is the token as written in the source, value is the unquoted string of a tokenString.
+	text  string
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.text)
+}
+
+// is reports whether t is the identifier word, ignoring case.
+func (t token) is(word string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, word)
+}
+
+// lex splits src into tokens, ending with a tokenEOF.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for {
+		for pos < len(src) {
+			r, size := utf8.DecodeRuneInString(src[pos:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			pos += size
+		}
+		if pos == len(src) {
+			return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+		}
+
+		start := pos
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		switch {
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: start})
+			pos++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: start})
+			pos++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: start})
+			pos++
+		case r == '=':
+			tokens = append(tokens, token{kind: tokenOperator, text: "=", pos: start})
+			pos++
+		case r == '!' || r == '<' || r == '>':
+			pos++
+			if pos < len(src) && src[pos] == '=' {
+				pos++
+			} else if r == '!' {
+				return nil, newError(src, start, `unexpected "!", did you mean "!="?`)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: src[start:pos], pos: start})
+		case r == '"':
+			end, err := scanString(src, start)
+			if err != nil {
+				return nil, err
+			}
+			value, err := strconv.Unquote(src[start:end])
+			if err != nil {
+				return nil, newError(src, start, "invalid string %v", src[start:end])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[start:end], value: value, pos: start})
+			pos = end
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			pos += size
+			for pos < len(src) && (src[pos] == '.' || isDigit(src[pos])) {
+				pos++
+			}
+			if _, err := strconv.ParseFloat(src[start:pos], 64); err != nil {
+				return nil, newError(src, start, "invalid number %q", src[start:pos])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:pos], pos: start})
+		case isIdentRune(r):
+			for pos < len(src) {
+				r, size := utf8.DecodeRuneInString(src[pos:])
+				if !isIdentRune(r) && !unicode.IsDigit(r) {
+					break
+				}
+				pos += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:pos], pos: start})
+		default:
+			return nil, newError(src, start, "unexpected %q", r)
+		}
+	}
+}
+
+// scanString returns the offset just past the string starting at src[start].
+func scanString(src string, start int) (int, error) {
+	for pos := start + 1; pos < len(src); pos++ {
+		switch src[pos] {
+		case '\\':
+			pos++
+		case '"':
+			return pos + 1, nil
+		}
+	}
+	return 0, newError(src, start, "unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
@@ -0,0 +1,291 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dateKeywords are the relative dates that can follow "is".
+var dateKeywords = []string{"past_week", "past_month", "past_year", "next_week", "next_month", "next_year"}
+
+type node interface {
+	pos() int
+}
+
+// logical is an "and" or an "or" of its operands.
+type logical struct {
+	op       string
+	operands []node
+	at       int
+}
+
+func (l *logical) pos() int { return l.at }
+
+// comparison is a condition on a property. op is one of "=", "!=", ">", ">=", "<", "<=", "contains",
+// "not contains", "starts with", "ends with", "is empty", "is not empty" or one of dateKeywords; value is nil
+// for the operators that don't take one.
+type comparison struct {
+	property string
+	op       string
+	opAt     int
+	value    *value
+	at       int
+}
+
+func (c *comparison) pos() int { return c.at }
+
+type valueKind string
+
+const (
+	stringValue valueKind = "string"
+	numberValue valueKind = "number"
+	boolValue   valueKind = "boolean"
+)
+
+// value is a literal; raw is how it's written in the query.
+type value struct {
+	kind    valueKind
+	raw     string
+	text    string
+	number  float64
+	boolean bool
+	at      int
+}
+
+type sortKey struct {
+	property  string
+	direction string
+	at        int
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the identifiers words if they come next, ignoring case.
+func (p *parser) accept(words ...string) bool {
+	if p.i+len(words) > len(p.tokens) {
+		return false
+	}
+	for j, word := range words {
+		if !p.tokens[p.i+j].is(word) {
+			return false
+		}
+	}
+	p.i += len(words)
+	return true
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) *Error {
+	return newError(p.src, t.pos, format, args...)
+}
+
+// parse parses the whole query:
+//
+//	query := [or] ["order" "by" sort ("," sort)*]
+func (p *parser) parse() (*Query, error) {
+	q := &Query{src: p.src}
+
+	if !p.atOrderBy() && p.peek().kind != tokenEOF {
+		filter, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		q.filter = filter
+	}
+
+	if p.accept("order", "by") {
+		for {
+			key, err := p.sortKey()
+			if err != nil {
+				return nil, err
+			}
+			q.sorts = append(q.sorts, key)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, `unexpected %v, expected "and", "or" or "order by"`, t)
+	}
+	return q, nil
+}
+
+func (p *parser) atOrderBy() bool {
+	return p.peek().is("order") && p.tokens[p.i+1].is("by")
+}
+
+// or parses: or := and ("or" and)*
+func (p *parser) or() (node, error) {
+	return p.logical("or", p.and)
+}
+
+// and parses: and := operand ("and" operand)*
+func (p *parser) and() (node, error) {
+	return p.logical("and", p.operand)
+}
+
+func (p *parser) logical(op string, operand func() (node, error)) (node, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &logical{op: op, at: first.pos()}
+	l.add(first)
+	for p.accept(op) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		l.add(next)
+	}
+
+	if len(l.operands) == 1 {
+		return first, nil
+	}
+	return l, nil
+}
+
+// add appends n to the operands of l, flattening a parenthesized operand that uses the same operator.
+func (l *logical) add(n node) {
+	if other, ok := n.(*logical); ok && other.op == l.op {
+		l.operands = append(l.operands, other.operands...)
+		return
+	}
+	l.operands = append(l.operands, n)
+}
+
+// operand parses: operand := "(" or ")" | comparison
+func (p *parser) operand() (node, error) {
+	if p.peek().kind != tokenLParen {
+		return p.comparison()
+	}
+
+	open := p.next()
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.kind != tokenRParen {
+		return nil, p.errorf(t, `expected ")" to close the "(" at column %d, found %v`,
+			newError(p.src, open.pos, "").Column, t)
+	}
+	return n, nil
+}
+
+// comparison parses a property followed by an operator and, for most operators, a value.
+func (p *parser) comparison() (node, error) {
+	property, err := p.property()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &comparison{property: property.value, at: property.pos, opAt: p.peek().pos}
+	t := p.next()
+	switch {
+	case t.kind == tokenOperator:
+		c.op = t.text
+	case t.is("contains"):
+		c.op = "contains"
+	case t.is("not") && p.accept("contains"), t.is("does") && p.accept("not", "contain"):
+		c.op = "not contains"
+	case t.is("starts") && p.accept("with"):
+		c.op = "starts with"
+	case t.is("ends") && p.accept("with"):
+		c.op = "ends with"
+	case t.is("is"):
+		return p.is(c)
+	default:
+		return nil, p.errorf(t, "expected an operator after %q, found %v", c.property, t)
+	}
+
+	c.value, err = p.value()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// is parses what follows "is": empty, not empty or a relative date.
+func (p *parser) is(c *comparison) (node, error) {
+	switch {
+	case p.accept("empty"):
+		c.op = "is empty"
+		return c, nil
+	case p.accept("not", "empty"):
+		c.op = "is not empty"
+		return c, nil
+	}
+
+	for _, keyword := range dateKeywords {
+		if p.accept(keyword) {
+			c.op = keyword
+			return c, nil
+		}
+	}
+	return nil, p.errorf(p.peek(), `expected "empty", "not empty" or one of %v after "is", found %v`,
+		strings.Join(dateKeywords, ", "), p.peek())
+}
+
+// property parses a property name, either a word or a string.
+func (p *parser) property() (token, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return t, nil
+	case t.kind == tokenIdent && !t.is("and") && !t.is("or"):
+		t.value = t.text
+		return t, nil
+	}
+	return t, p.errorf(t, "expected a property name, found %v", t)
+}
+
+func (p *parser) value() (*value, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return &value{kind: stringValue, raw: t.text, text: t.value, at: t.pos}, nil
+	case t.kind == tokenNumber:
+		number, _ := strconv.ParseFloat(t.text, 64)
+		return &value{kind: numberValue, raw: t.text, number: number, at: t.pos}, nil
+	case t.is("true"), t.is("false"):
+		return &value{kind: boolValue, raw: t.text, boolean: t.is("true"), at: t.pos}, nil
+	case t.kind == tokenIdent:
+		return nil, p.errorf(t, "expected a value, found %v; strings must be double-quoted", t)
+	}
+	return nil, p.errorf(t, "expected a value, found %v", t)
+}
+
+// sortKey parses: sort := property ["asc" | "ascending" | "desc" | "descending"]
+func (p *parser) sortKey() (sortKey, error) {
+	property, err := p.property()
+	if err != nil {
+		return sortKey{}, err
+	}
+
+	key := sortKey{property: property.value, direction: "ascending", at: property.pos}
+	switch {
+	case p.accept("asc"), p.accept("ascending"):
+	case p.accept("desc"), p.accept("descending"):
+		key.direction = "descending"
+	}
+	return key, nil
+}
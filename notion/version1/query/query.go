@@ -0,0 +1,99 @@
+// Package query compiles a small text query language into Notion database query parameters.
+//
+// A query is a filter expression optionally followed by sort keys:
+//
+//	Status = "Done" and Priority > 2 and (Tags contains "infra" or Due is past_week) order by Priority desc
+//
+// Comparisons start with a property name, quoted if it isn't a single word ("Due Date" is empty), followed by one
+// of the operators =, !=, >, >=, <, <=, contains, not contains, starts with, ends with, is empty and is not empty,
+// or a relative date: is past_week, is past_month, is past_year, is next_week, is next_month, is next_year.
+// Values are double-quoted strings, numbers, true or false; dates are strings such as "2021-05-10".
+// Keywords are case-insensitive, and "and" binds tighter than "or".
+//
+// Compile uses the database schema to pick the condition each comparison needs, so the same query works on a
+// select, a text or a formula property.
+package query
+
+import (
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a problem at a position of a query.
+// Pos is a byte offset into the query, Line and Column are 1-based, and Column counts characters.
+type Error struct {
+	Pos     int
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("query:%d:%d: %v", e.Line, e.Column, e.Message)
+}
+
+func newError(src string, pos int, format string, args ...interface{}) *Error {
+	before := src[:pos]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return &Error{Pos: pos, Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Query is a parsed query, ready to be compiled against a database.
+type Query struct {
+	src    string
+	filter node
+	sorts  []sortKey
+}
+
+// Parse parses src. Errors are of type *Error.
+func Parse(src string) (*Query, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: src, tokens: tokens}
+	return p.parse()
+}
+
+// Compile parses src and compiles it against db. See Query.Compile.
+func Compile(src string, db *notion.Database) (*notion.QueryDatabaseBodyParams, error) {
+	q, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return q.Compile(db)
+}
+
+// Compile turns q into the filter and sorts of a database query, using db, as returned by RetrieveDatabase, to
+// pick the condition of each comparison.
+// Comparisons that don't suit their property, e.g. contains on a number, are reported as an *Error. The result
+// is then checked with notion.ValidateQuery, whose *notion.QueryValidationError is returned as is.
+func (q *Query) Compile(db *notion.Database) (*notion.QueryDatabaseBodyParams, error) {
+	c := &compiler{src: q.src, db: db}
+
+	params := &notion.QueryDatabaseBodyParams{}
+	if q.filter != nil {
+		f, err := c.compile(q.filter)
+		if err != nil {
+			return nil, err
+		}
+		params.Filter = f.Build()
+	}
+
+	for _, key := range q.sorts {
+		sort, err := c.sort(key)
+		if err != nil {
+			return nil, err
+		}
+		params.Sorts = append(params.Sorts, sort)
+	}
+
+	if err := notion.ValidateQuery(db, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
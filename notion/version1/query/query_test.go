@@ -0,0 +1,131 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/query"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testDB = &notion.Database{
+	Object: "database",
+	ID:     "123",
+	Properties: map[string]notion.PropertyObj{
+		"Name":     {Type: "title"},
+		"Status":   {Type: "select"},
+		"Priority": {Type: "number"},
+		"Tags":     {Type: "multi_select"},
+		"Done":     {Type: "checkbox"},
+		"Due Date": {Type: "date"},
+		"Owner":    {Type: "people"},
+		"Score":    {Type: "formula"},
+	},
+}
+
+func assertCompiles(t *testing.T, expected, src string) {
+	params, err := query.Compile(src, testDB)
+	if !assert.Nil(t, err, src) {
+		return
+	}
+
+	data, err := json.Marshal(params)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(data), src)
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{`Status = "Done"`, `{"filter":{"property":"Status","select":{"equals":"Done"}}}`},
+		{`Name contains "x"`, `{"filter":{"property":"Name","title":{"contains":"x"}}}`},
+		{`Name does not contain "x"`, `{"filter":{"property":"Name","title":{"does_not_contain":"x"}}}`},
+		{`Name starts with "x"`, `{"filter":{"property":"Name","title":{"starts_with":"x"}}}`},
+		{`Priority >= 2.5`, `{"filter":{"property":"Priority","number":{"greater_than_or_equal_to":2.5}}}`},
+		{`Priority = 0`, `{"filter":{"property":"Priority","number":{"equals":0}}}`},
+		{`Done = false`, `{"filter":{"property":"Done","checkbox":{"equals":false}}}`},
+		{`Tags not contains "infra"`, `{"filter":{"property":"Tags","multi_select":{"does_not_contain":"infra"}}}`},
+		{`"Due Date" is past_week`, `{"filter":{"property":"Due Date","date":{"past_week":{}}}}`},
+		{`"Due Date" < "2021-05-10"`, `{"filter":{"property":"Due Date","date":{"before":"2021-05-10"}}}`},
+		{`"Due Date" IS NOT EMPTY`, `{"filter":{"property":"Due Date","date":{"is_not_empty":true}}}`},
+		{`Owner contains "abc"`, `{"filter":{"property":"Owner","people":{"contains":"abc"}}}`},
+		{`Score > 10`, `{"filter":{"property":"Score","formula":{"number":{"greater_than":10}}}}`},
+		{`Score = "high"`, `{"filter":{"property":"Score","formula":{"text":{"equals":"high"}}}}`},
+		{`order by Priority desc, created_time`,
+			`{"sorts":[{"property":"Priority","direction":"descending"},{"timestamp":"created_time","direction":"ascending"}]}`},
+		{``, `{}`},
+	}
+
+	for _, c := range cases {
+		assertCompiles(t, c.expected, c.src)
+	}
+}
+
+func TestCompile_Logical(t *testing.T) {
+	assertCompiles(t,
+		`{"filter":{"and":[{"property":"Status","select":{"equals":"Done"}},{"property":"Priority","number":{"greater_than":2}},`+
+			`{"property":"Tags","multi_select":{"contains":"infra"}}]}}`,
+		`Status = "Done" and Priority > 2 and Tags contains "infra"`)
+
+	// "and" binds tighter than "or", and parentheses using the same operator are flattened.
+	assertCompiles(t,
+		`{"filter":{"or":[{"property":"Done","checkbox":{"equals":true}},{"and":[{"property":"Priority","number":{"less_than":1}},`+
+			`{"property":"Status","select":{"is_empty":true}},{"property":"Tags","multi_select":{"is_empty":true}}]}]},`+
+			`"sorts":[{"property":"Due Date","direction":"ascending"}]}`,
+		"Done = true OR Priority < 1 and (Status is empty and Tags is empty)\norder by \"Due Date\" asc")
+}
+
+func TestCompile_Errors(t *testing.T) {
+	cases := []struct {
+		src    string
+		line   int
+		column int
+		msg    string
+	}{
+		{`Status = Done`, 1, 10, `expected a value, found "Done"; strings must be double-quoted`},
+		{`Status = "Done`, 1, 10, `unterminated string`},
+		{`Status ~ "Done"`, 1, 8, `unexpected '~'`},
+		{`(Status = "Done"`, 1, 17, `expected ")" to close the "(" at column 1, found end of query`},
+		{`Status = "Done" Priority > 2`, 1, 17, `unexpected "Priority", expected "and", "or" or "order by"`},
+		{"Status = \"Done\" and\n  Priority is soon", 2, 15, `expected "empty", "not empty" or one of ` +
+			`past_week, past_month, past_year, next_week, next_month, next_year after "is", found "soon"`},
+		{`Stauts = "Done"`, 1, 1, `unknown property "Stauts"`},
+		{`Priority contains "2"`, 1, 10, `"contains" cannot be used on number property "Priority"`},
+		{`Priority > "2"`, 1, 12, `number property "Priority" must be compared to a number, found string "2"`},
+		{`order by Nmae`, 1, 10, `unknown property "Nmae"`},
+		{`Name contains ""`, 1, 15, `title property "Name" cannot be compared to an empty string; use "is empty" or ` +
+			`"is not empty"`},
+		{`Name starts with ""`, 1, 18, `title property "Name" cannot be compared to an empty string; use "is empty" or ` +
+			`"is not empty"`},
+		{`Name ends with ""`, 1, 16, `title property "Name" cannot be compared to an empty string; use "is empty" or ` +
+			`"is not empty"`},
+		{`Tags contains ""`, 1, 15, `multi_select property "Tags" cannot be compared to an empty string; use ` +
+			`"is empty" or "is not empty"`},
+	}
+
+	for _, c := range cases {
+		_, err := query.Compile(c.src, testDB)
+
+		var queryErr *query.Error
+		if assert.True(t, errors.As(err, &queryErr), c.src) {
+			assert.Equal(t, c.line, queryErr.Line, c.src)
+			assert.Equal(t, c.column, queryErr.Column, c.src)
+			assert.Equal(t, c.msg, queryErr.Message, c.src)
+		}
+	}
+}
+
+func TestCompile_Validation(t *testing.T) {
+	_, err := query.Compile(`Done = true or (Priority > 1 and (Status = "a" or Status = "b"))`, testDB)
+
+	var validationErr *notion.QueryValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestQuery_Error(t *testing.T) {
+	_, err := query.Parse(`Status =`)
+	assert.Equal(t, "query:1:9: expected a value, found end of query", err.Error())
+}
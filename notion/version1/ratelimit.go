@@ -0,0 +1,97 @@
+package version1
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiter for requests made to the Notion API. Notion allows an average of three
+// requests per second per integration, so a limiter of NewRateLimiter(3, 3) keeps a process under that limit.
+// https://developers.notion.com/reference/errors#request-limits
+//
+// Requests are limited per access token. A single RateLimiter can be shared by Clients that use different
+// access tokens, and each token gets its own bucket.
+type RateLimiter struct {
+	rate  float64
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows requestsPerSecond requests on average per access token,
+// with bursts of up to burst requests. A requestsPerSecond of zero or less disables limiting.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:    requestsPerSecond,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Wait blocks until a request for key (an access token) is allowed or ctx is done.
+// It returns ctx.Err() if ctx is done before the request is allowed.
+func (l *RateLimiter) Wait(ctx context.Context, key string) error {
+	_, err := l.wait(ctx, key)
+	return err
+}
+
+// wait is Wait, also returning how long the request was delayed.
+func (l *RateLimiter) wait(ctx context.Context, key string) (time.Duration, error) {
+	if l == nil || l.rate <= 0 {
+		return 0, ctx.Err()
+	}
+
+	wait := l.reserve(key, time.Now())
+	if err := sleep(ctx, wait); err != nil {
+		l.cancel(key)
+		return 0, err
+	}
+
+	return wait, nil
+}
+
+// reserve takes a token from key's bucket and returns how long the caller must wait before using it.
+func (l *RateLimiter) reserve(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve that ended up unused.
+func (l *RateLimiter) cancel(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.tokens++
+	}
+}
@@ -0,0 +1,68 @@
+package version1_test
+
+import (
+	"context"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := notion.NewRateLimiter(20, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background(), "token"))
+	}
+	// The first request uses the burst, the next two wait 50ms each.
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+}
+
+func TestRateLimiter_Wait_PerToken(t *testing.T) {
+	limiter := notion.NewRateLimiter(1, 1)
+
+	start := time.Now()
+	assert.Nil(t, limiter.Wait(context.Background(), "token-a"))
+	assert.Nil(t, limiter.Wait(context.Background(), "token-b"))
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestRateLimiter_Wait_ContextCancelled(t *testing.T) {
+	limiter := notion.NewRateLimiter(1, 1)
+	assert.Nil(t, limiter.Wait(context.Background(), "token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx, "token"))
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testUserJSON)
+	})
+
+	limiter := notion.NewRateLimiter(1, 1)
+	client := notion.NewClient(httpClient, "0000")
+	client.RateLimiter = limiter
+
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = client.Users.RetrieveUser(ctx, "123")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// A client with another access token has its own bucket.
+	other := notion.NewClient(httpClient, "1111")
+	other.RateLimiter = limiter
+	_, _, err = other.Users.RetrieveUser(context.Background(), "123")
+	assert.Nil(t, err)
+}
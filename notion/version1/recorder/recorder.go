@@ -0,0 +1,330 @@
+// Package recorder records the HTTP interactions of a client with Notion to a cassette file, and replays them in
+// later runs, so tests can exercise real responses deterministically and offline.
+//
+// A Recorder is an http.RoundTripper, used through the http.Client passed to NewClient or AuthClient:
+//
+//	rec, err := recorder.New("testdata/query.json", recorder.Replay)
+//	if err != nil {
+//		// handle err
+//	}
+//	client := notion.NewClient(rec.Client(), token)
+//
+// Run once in Record mode with a real token to create the cassette. Cassettes never hold the Authorization header,
+// which carries the access token and the OAuth client secret, nor client secrets and access tokens in bodies.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode is what a Recorder does with requests.
+type Mode int
+
+const (
+	// Replay answers requests with the recorded interactions, without sending them.
+	Replay Mode = iota
+
+	// Record sends requests and saves the interactions to the cassette, replacing what it held.
+	Record
+
+	// Passthrough sends requests without recording or replaying anything.
+	Passthrough
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Replay:
+		return "replay"
+	case Record:
+		return "record"
+	case Passthrough:
+		return "passthrough"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// redacted replaces secrets in cassettes.
+const redacted = "REDACTED"
+
+// ErrNoInteraction is returned, wrapped, when replaying a request that wasn't recorded.
+var ErrNoInteraction = errors.New("recorder: no recorded interaction matches the request")
+
+// Cassette is what a cassette file holds.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder records and replays interactions. Its methods are safe for concurrent use.
+type Recorder struct {
+	// Transport sends requests in Record and Passthrough modes. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Redact, if set, is called on every interaction before it is saved, to remove secrets the Recorder doesn't
+	// know about.
+	Redact func(interaction *Interaction)
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New returns a Recorder using the cassette at path in mode. In Replay mode, the cassette must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode != Replay {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("recorder: decoding cassette %v: %w", path, err)
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Mode returns the mode of r.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an http.Client that sends its requests through r.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records, replays or passes req through, depending on r's mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case Replay:
+		return r.replay(req)
+	case Record:
+		return r.record(req)
+	}
+	return r.transport().RoundTrip(req)
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport != nil {
+		return r.Transport
+	}
+	return http.DefaultTransport
+}
+
+// replay answers req with the first interaction that matches it and hasn't been replayed yet, so repeated requests
+// get their responses in the order they were recorded.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := redactRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.replayed[i] = true
+		return interaction.Response.response(req), nil
+	}
+	return nil, fmt.Errorf("%w: %v %v", ErrNoInteraction, req.Method, req.URL)
+}
+
+// record sends req, and saves the interaction to the cassette before returning the response.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: redactRequest(req, body),
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       redactBody(string(respBody), "access_token"),
+		},
+	}
+	if r.Redact != nil {
+		r.Redact(&interaction)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the cassette to r.path, creating its directory if needed.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("recorder: encoding cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("recorder: saving cassette: %w", err)
+	}
+	if err := ioutil.WriteFile(r.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("recorder: saving cassette: %w", err)
+	}
+	return nil
+}
+
+// readBody reads the body of req and replaces it, so it can still be sent.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// redactRequest returns req as it is recorded, without its secrets.
+func redactRequest(req *http.Request, body []byte) Request {
+	header := req.Header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", redacted)
+	}
+	return Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: header,
+		Body:   redactBody(string(body), "client_secret"),
+	}
+}
+
+// redactBody replaces the values of fields in a JSON object or form encoded body.
+func redactBody(body string, fields ...string) string {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &object); err == nil {
+		changed := false
+		for _, field := range fields {
+			if _, ok := object[field]; ok {
+				object[field], changed = redacted, true
+			}
+		}
+		if !changed {
+			return body
+		}
+		data, err := json.Marshal(object)
+		if err != nil {
+			return body
+		}
+		return string(data)
+	}
+
+	if values, err := url.ParseQuery(body); err == nil && strings.Contains(body, "=") {
+		changed := false
+		for _, field := range fields {
+			if values.Get(field) != "" {
+				values.Set(field, redacted)
+				changed = true
+			}
+		}
+		if changed {
+			return values.Encode()
+		}
+	}
+	return body
+}
+
+// matches reports whether a request matches a recorded one: same method, path and query, and the same body once
+// JSON bodies are normalized.
+func matches(recorded, req Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	a, errA := url.Parse(recorded.URL)
+	b, errB := url.Parse(req.URL)
+	if errA != nil || errB != nil || a.Path != b.Path || !reflect.DeepEqual(a.Query(), b.Query()) {
+		return false
+	}
+	return sameBody(recorded.Body, req.Body)
+}
+
+// sameBody compares JSON bodies by value, ignoring formatting and the order of object keys, and other bodies as
+// they are.
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var valueA, valueB interface{}
+	if json.Unmarshal([]byte(a), &valueA) != nil || json.Unmarshal([]byte(b), &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// response returns the recorded response to req.
+func (r Response) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %v", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,123 @@
+package recorder_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/oyekanmiayo/go-notion/notion/version1/notiontest"
+	"github.com/oyekanmiayo/go-notion/notion/version1/recorder"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "testdata", "users.json")
+	server := notiontest.NewServer()
+	server.AddUser(notion.User{Name: "Ada"})
+	server.AddUser(notion.User{Name: "Grace"})
+
+	rec, err := recorder.New(cassette, recorder.Record)
+	assert.Nil(t, err)
+	client := notion.NewClient(rec.Client(), "secret_token", notion.WithBaseURL(server.URL))
+	recorded, _, err := client.Users.ListUsers(context.Background(), &notion.ListUsersQueryParams{PageSize: 1})
+	assert.Nil(t, err)
+	_, _, err = client.Users.RetrieveUser(context.Background(), "unknown")
+	assert.True(t, notion.IsNotFound(err))
+	server.Close()
+
+	data, err := ioutil.ReadFile(cassette)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "secret_token")
+	assert.Contains(t, string(data), `"Authorization": [`)
+
+	rec, err = recorder.New(cassette, recorder.Replay)
+	assert.Nil(t, err)
+	client = notion.NewClient(rec.Client(), "another_token", notion.WithBaseURL(server.URL))
+	replayed, _, err := client.Users.ListUsers(context.Background(), &notion.ListUsersQueryParams{PageSize: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, recorded, replayed)
+	_, _, err = client.Users.RetrieveUser(context.Background(), "unknown")
+	assert.True(t, notion.IsNotFound(err))
+
+	// Every interaction is only replayed once.
+	_, _, err = client.Users.ListUsers(context.Background(), &notion.ListUsersQueryParams{PageSize: 1})
+	assert.True(t, errors.Is(err, recorder.ErrNoInteraction))
+	_, _, err = client.Users.ListUsers(context.Background(), &notion.ListUsersQueryParams{PageSize: 2})
+	assert.True(t, errors.Is(err, recorder.ErrNoInteraction))
+}
+
+func TestRecorder_MatchesNormalizedJSON(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "query.json")
+	assert.Nil(t, ioutil.WriteFile(cassette, []byte(`{"interactions": [{
+		"request": {"method": "POST", "url": "https://api.notion.com/v1/databases/d1/query?x=1&y=2",
+			"body": "{\"page_size\": 10, \"sorts\": [{\"property\": \"Name\"}]}"},
+		"response": {"status_code": 200, "body": "{\"object\": \"list\", \"results\": []}"}
+	}]}`), 0644))
+
+	rec, err := recorder.New(cassette, recorder.Replay)
+	assert.Nil(t, err)
+	resp, err := rec.Client().Post("https://api.notion.com/v1/databases/d1/query?y=2&x=1", "application/json",
+		strings.NewReader(`{"sorts":[{"property":"Name"}],"page_size":10}`))
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `{"object": "list", "results": []}`, string(body))
+
+	_, err = recorder.New(filepath.Join(t.TempDir(), "missing.json"), recorder.Replay)
+	assert.NotNil(t, err)
+}
+
+func TestRecorder_RedactsOAuthSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "secret_access", "bot_id": "b1"}`)
+	}))
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "oauth.json")
+	rec, err := recorder.New(cassette, recorder.Record)
+	assert.Nil(t, err)
+	client := notion.AuthClient(rec.Client(), notion.WithBaseURL(server.URL))
+	config := &oauth2.Config{ClientID: "client", ClientSecret: "client_secret_value"}
+	token, _, err := client.Auth.AccessToken(context.Background(), config, "code")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret_access", token.AccessToken)
+
+	data, err := ioutil.ReadFile(cassette)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "client_secret_value")
+	// The client secret is sent base64 encoded, in the Authorization header.
+	assert.NotContains(t, string(data), "Basic ")
+	assert.NotContains(t, string(data), "secret_access")
+
+	rec, err = recorder.New(cassette, recorder.Replay)
+	assert.Nil(t, err)
+	client = notion.AuthClient(rec.Client(), notion.WithBaseURL(server.URL))
+	token, _, err = client.Auth.AccessToken(context.Background(), config, "code")
+	assert.Nil(t, err)
+	assert.Equal(t, "b1", token.BotID)
+}
+
+func TestRecorder_Passthrough(t *testing.T) {
+	server := notiontest.NewServer()
+	defer server.Close()
+	server.AddUser(notion.User{Name: "Ada"})
+
+	cassette := filepath.Join(t.TempDir(), "none.json")
+	rec, err := recorder.New(cassette, recorder.Passthrough)
+	assert.Nil(t, err)
+	client := notion.NewClient(rec.Client(), "token", notion.WithBaseURL(server.URL))
+	users, _, err := client.Users.ListUsers(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, users.Results, 1)
+
+	_, err = ioutil.ReadFile(cassette)
+	assert.NotNil(t, err)
+}
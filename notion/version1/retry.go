@@ -0,0 +1,110 @@
+package version1
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with 429 rate_limited, 502, 503 or 504 are retried.
+// Calls that are not safe to repeat (e.g. CreatePage and AppendBlockChildren) are only retried after a 429,
+// since Notion rejects rate limited requests before acting on them.
+// https://developers.notion.com/reference/errors#request-limits
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on every following retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. If Notion asks for a longer wait via Retry-After,
+	// the failed response is returned instead of waiting.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most integrations.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// retryable reports whether a response with the given status code may be retried.
+func (p *RetryPolicy) retryable(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns the delay before retry number n (starting at 1), with jitter applied.
+// It returns false if the wait requested by resp exceeds MaxBackoff.
+func (p *RetryPolicy) backoff(n int, resp *http.Response) (time.Duration, bool) {
+	if wait, ok := retryAfter(resp); ok {
+		return wait, p.MaxBackoff <= 0 || wait <= p.MaxBackoff
+	}
+
+	delay := p.MinBackoff
+	for i := 1; i < n && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+
+	// Wait somewhere between half and all of delay, so concurrent callers don't retry in lockstep.
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1)), true
+}
+
+// retryAfter returns the wait requested by the Retry-After header of resp, if any.
+// The header can either hold a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for d to elapse or for ctx to be done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package version1_test
+
+import (
+	"context"
+	"fmt"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var (
+	testRateLimitedJSON        = `{"object":"error","status":429,"code":"rate_limited","message":"You have been rate limited."}`
+	testServiceUnavailableJSON = `{"object":"error","status":503,"code":"service_unavailable","message":"Notion is unavailable."}`
+	testRetryPolicy            = &notion.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+)
+
+func TestClient_RetryPolicy_RateLimited(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/databases/123/query", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assertPostJSON(t, testSingleFilterParamsJSON, r)
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprintf(w, testRateLimitedJSON)
+			return
+		}
+		fmt.Fprintf(w, testFilterResJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	client.RetryPolicy = testRetryPolicy
+	resp, _, err := client.Databases.QueryDatabase(context.Background(), "123", testSingleFilterParams)
+	assert.Nil(t, err)
+	assert.Equal(t, testFilterRes, resp)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RetryPolicy_GivesUp(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, testServiceUnavailableJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	client.RetryPolicy = testRetryPolicy
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Equal(t, "service_unavailable", err.(notion.APIError).Code)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClient_RetryPolicy_NonIdempotent(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/pages/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, testServiceUnavailableJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	client.RetryPolicy = testRetryPolicy
+	_, _, err := client.Pages.CreatePage(context.Background(), testCreatePageBody)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_RetryPolicy_RetryAfterTooLong(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, testRateLimitedJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	client.RetryPolicy = testRetryPolicy
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.Equal(t, "rate_limited", err.(notion.APIError).Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_NoRetryPolicy(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/v1/users/123", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, testRateLimitedJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	_, _, err := client.Users.RetrieveUser(context.Background(), "123")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,58 @@
+package version1
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTextLength is the most characters Notion accepts in the content of one rich text object.
+// https://developers.notion.com/reference/request-limits
+const MaxTextLength = 2000
+
+// Content returns the text of rt. It doesn't rely on PlainText, which Notion only sets in responses.
+func (rt RichText) Content() string {
+	switch {
+	case rt.Text != nil && rt.Text.Content != "":
+		return rt.Text.Content
+	case rt.PlainText != "":
+		return rt.PlainText
+	case rt.Equation != nil:
+		return rt.Equation.Expression
+	}
+	return ""
+}
+
+// PlainText returns the text of richText, without annotations.
+func PlainText(richText []RichText) string {
+	var b strings.Builder
+	for _, rt := range richText {
+		b.WriteString(rt.Content())
+	}
+	return b.String()
+}
+
+// SplitText splits text rich text whose content is longer than MaxTextLength into several with the same annotations
+// and link. Other rich text is returned as it is.
+func SplitText(rt RichText) []RichText {
+	if rt.Text == nil {
+		return []RichText{rt}
+	}
+
+	var richText []RichText
+	content := rt.Text.Content
+	for len(content) > 0 || len(richText) == 0 {
+		n := len(content)
+		if utf8.RuneCountInString(content) > MaxTextLength {
+			n = 0
+			for i := 0; i < MaxTextLength; i++ {
+				_, size := utf8.DecodeRuneInString(content[n:])
+				n += size
+			}
+		}
+		piece := rt
+		piece.Text = &Text{Content: content[:n], Link: rt.Text.Link}
+		richText = append(richText, piece)
+		content = content[n:]
+	}
+	return richText
+}
@@ -0,0 +1,43 @@
+package version1_test
+
+import (
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+	richText := []notion.RichText{
+		{Type: "text", Text: &notion.Text{Content: "E = "}},
+		{Type: "equation", Equation: &notion.Equation{Expression: "mc^2"}},
+		{Type: "mention", PlainText: " @Ada"},
+	}
+	assert.Equal(t, "E = mc^2 @Ada", notion.PlainText(richText))
+	assert.Equal(t, "", notion.PlainText(nil))
+}
+
+func TestSplitText(t *testing.T) {
+	bold := &notion.Annotations{Bold: true}
+	link := &notion.Link{URL: "https://example.com"}
+	content := strings.Repeat("é", notion.MaxTextLength) + "abc"
+
+	pieces := notion.SplitText(notion.RichText{
+		Type:        "text",
+		Annotations: bold,
+		Text:        &notion.Text{Content: content, Link: link},
+	})
+	if assert.Len(t, pieces, 2) {
+		assert.Equal(t, strings.Repeat("é", notion.MaxTextLength), pieces[0].Text.Content)
+		assert.Equal(t, "abc", pieces[1].Text.Content)
+		for _, piece := range pieces {
+			assert.Equal(t, bold, piece.Annotations)
+			assert.Equal(t, link, piece.Text.Link)
+		}
+	}
+
+	empty := notion.RichText{Type: "text", Text: &notion.Text{}}
+	assert.Equal(t, []notion.RichText{empty}, notion.SplitText(empty))
+	equation := notion.RichText{Type: "equation", Equation: &notion.Equation{Expression: "x"}}
+	assert.Equal(t, []notion.RichText{equation}, notion.SplitText(equation))
+}
@@ -1,6 +1,8 @@
 package version1
 
 import (
+	"context"
+	"encoding/json"
 	"github.com/dghubble/sling"
 	"net/http"
 )
@@ -39,10 +41,68 @@ type SearchFilter struct {
 	Property string `json:"property,omitempty"`
 }
 
-// TODO: Find a way to unify responses and make them accessible.
-// Use json.RawMessage & https://play.golang.org/p/e6kvxtOeTCc
-// One approach: Unmarshal into a random struct to get the type of
-// each entry, and use that to Unnmarshal into the right struct.
+type SearchResponse struct {
+	Object     string         `json:"object,omitempty"`
+	Results    []SearchResult `json:"results,omitempty"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more,omitempty"`
+}
+
+// SearchResult is either a page or a database, depending on Object.
+// Object is either "page" or "database". Page is set for pages and Database is set for databases.
+// Results of other objects keep their content in Raw, and are encoded back as they came.
+type SearchResult struct {
+	Object   string
+	Page     *Page
+	Database *Database
+
+	// Raw is the content of a result that is neither a page nor a database, as it was received.
+	Raw json.RawMessage
+}
+
+func (r *SearchResult) UnmarshalJSON(data []byte) error {
+	var object struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	*r = SearchResult{Object: object.Object}
+	switch object.Object {
+	case "page":
+		r.Page = new(Page)
+		return json.Unmarshal(data, r.Page)
+	case "database":
+		r.Database = new(Database)
+		return json.Unmarshal(data, r.Database)
+	}
+
+	r.Raw = append(json.RawMessage{}, data...)
+	return nil
+}
+
+func (r SearchResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Page != nil:
+		return json.Marshal(r.Page)
+	case r.Database != nil:
+		return json.Marshal(r.Database)
+	case r.Raw != nil:
+		return r.Raw, nil
+	}
+	return []byte("null"), nil
+}
+
+// https://developers.notion.com/reference/post-search
+// Unlike SearchPage and SearchDatabase, Search leaves params.Filter as it is, so results can hold both
+// pages and databases.
+func (s *SearchService) Search(ctx context.Context, params *SearchBodyParams) (*SearchResponse, *http.Response, error) {
+	sResponse := new(SearchResponse)
+	httpResponse, err := receive(ctx, idempotent.named("search"), s.sling.New().Post("").BodyJSON(params), sResponse)
+
+	return sResponse, httpResponse, err
+}
 
 type SearchPageResponse struct {
 	Object     string `json:"object,omitempty"`
@@ -51,18 +111,17 @@ type SearchPageResponse struct {
 	HasMore    bool   `json:"has_more,omitempty"`
 }
 
-func (s *SearchService) SearchPage(params *SearchBodyParams) (*SearchPageResponse, *http.Response, error) {
+func (s *SearchService) SearchPage(ctx context.Context, params *SearchBodyParams) (*SearchPageResponse, *http.Response, error) {
 	sResponse := new(SearchPageResponse)
-	apiError := new(APIError)
 
 	params.Filter = &SearchFilter{
 		Property: "object",
 		Value:    "page",
 	}
 
-	httpResponse, httpError := s.sling.New().Post("").BodyJSON(params).Receive(sResponse, apiError)
+	httpResponse, err := receive(ctx, idempotent.named("search"), s.sling.New().Post("").BodyJSON(params), sResponse)
 
-	return sResponse, httpResponse, relevantError(httpError, *apiError)
+	return sResponse, httpResponse, err
 }
 
 type SearchDatabaseResponse struct {
@@ -72,15 +131,71 @@ type SearchDatabaseResponse struct {
 	HasMore    bool       `json:"has_more,omitempty"`
 }
 
-func (s *SearchService) SearchDatabase(params *SearchBodyParams) (*SearchDatabaseResponse, *http.Response, error) {
+func (s *SearchService) SearchDatabase(ctx context.Context,
+	params *SearchBodyParams) (*SearchDatabaseResponse, *http.Response, error) {
 	sResponse := new(SearchDatabaseResponse)
-	apiError := new(APIError)
 
 	params.Filter = &SearchFilter{
 		Property: "object",
 		Value:    "database",
 	}
-	httpResponse, httpError := s.sling.New().Post("").BodyJSON(params).Receive(sResponse, apiError)
+	httpResponse, err := receive(ctx, idempotent.named("search"), s.sling.New().Post("").BodyJSON(params), sResponse)
+
+	return sResponse, httpResponse, err
+}
+
+// SearchIter returns a SearchResultIter over every page and database that matches params, starting at
+// params.StartCursor.
+func (s *SearchService) SearchIter(ctx context.Context, params *SearchBodyParams) *SearchResultIter {
+	body := SearchBodyParams{}
+	if params != nil {
+		body = *params
+	}
+
+	it := &SearchResultIter{}
+	it.iterator = newIterator(ctx, body.StartCursor, body.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			body.StartCursor, body.PageSize = cursor, pageSize
+			response, _, err := s.Search(ctx, &body)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
+}
+
+// SearchPageIter returns a PageIter over every page that matches params, starting at params.StartCursor.
+func (s *SearchService) SearchPageIter(ctx context.Context, params *SearchBodyParams) *PageIter {
+	body := SearchBodyParams{}
+	if params != nil {
+		body = *params
+	}
+
+	it := &PageIter{}
+	it.iterator = newIterator(ctx, body.StartCursor, body.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			body.StartCursor, body.PageSize = cursor, pageSize
+			response, _, err := s.SearchPage(ctx, &body)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
+}
+
+// SearchDatabaseIter returns a DatabaseIter over every database that matches params, starting at
+// params.StartCursor.
+func (s *SearchService) SearchDatabaseIter(ctx context.Context, params *SearchBodyParams) *DatabaseIter {
+	body := SearchBodyParams{}
+	if params != nil {
+		body = *params
+	}
 
-	return sResponse, httpResponse, relevantError(httpError, *apiError)
+	it := &DatabaseIter{}
+	it.iterator = newIterator(ctx, body.StartCursor, body.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			body.StartCursor, body.PageSize = cursor, pageSize
+			response, _, err := s.SearchDatabase(ctx, &body)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
 }
@@ -1,6 +1,8 @@
 package version1_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,32 @@ var (
 			},
 		},
 	}
+
+	testSearchAllBodyJSON = `{"query":"Jamboree","sort":{"direction":"descending","timestamp":"last_edited_time"}}` + "\n"
+	testSearchAllBody     = &notion.SearchBodyParams{
+		Query: "Jamboree",
+		Sort: &notion.Sort{
+			Direction: "descending",
+			Timestamp: "last_edited_time",
+		},
+	}
+	testSearchAllResJSON = `{"object":"list","results":[` +
+		`{"object":"page","id":"5678","created_time":"2021-05-14T01:06:32.845Z","last_edited_time":"2021-05-23T08:02:00.000Z","parent":{"database_id":"38923","type":"database_id"},"properties":{"Name":{"id":"title","type":"title","title":[{"plain_text":"Jamboree"}]},"Recommended":{"id":"EZMA","type":"checkbox","checkbox":true},"Tags":{"id":"VSvn","type":"multi_select","multi_select":[{"id":"44645","name":"TagTest","color":"purple"}]}}},` +
+		`{"object":"database","id":"123","created_time":"2021-05-23T07:41:16.751Z","last_edited_time":"2021-05-23T07:41:00.000Z","title":[{"type":"text","text":{"content":"Jamboree"}}],"properties":{"Name":{"id":"title","type":"title","title":{}}}}` +
+		`]}` + "\n"
+	testSearchAllRes = &notion.SearchResponse{
+		Object: "list",
+		Results: []notion.SearchResult{
+			{
+				Object: "page",
+				Page:   testPage,
+			},
+			{
+				Object:   "database",
+				Database: &testSearchDBRes.Results[0],
+			},
+		},
+	}
 )
 
 func TestSearchService_SearchPage(t *testing.T) {
@@ -68,7 +96,7 @@ func TestSearchService_SearchPage(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Search.SearchPage(testSearchBody)
+	resp, _, err := client.Search.SearchPage(context.Background(), testSearchBody)
 	assert.Nil(t, err)
 	assert.Equal(t, testSearchPageRes, resp)
 }
@@ -86,7 +114,52 @@ func TestSearchService_SearchDatabase(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Search.SearchDatabase(testSearchBody)
+	resp, _, err := client.Search.SearchDatabase(context.Background(), testSearchBody)
 	assert.Nil(t, err)
 	assert.Equal(t, testSearchDBRes, resp)
 }
+
+func TestSearchService_Search(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v1/search/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "POST", r)
+		assertPostJSON(t, testSearchAllBodyJSON, r)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, testSearchAllResJSON)
+	})
+
+	client := notion.NewClient(httpClient, "0000")
+	resp, _, err := client.Search.Search(context.Background(), testSearchAllBody)
+	assert.Nil(t, err)
+	assert.Equal(t, testSearchAllRes, resp)
+}
+
+func TestSearchResult_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(testSearchAllRes.Results[1])
+	assert.Nil(t, err)
+
+	var result notion.SearchResult
+	assert.Nil(t, json.Unmarshal(data, &result))
+	assert.Equal(t, testSearchAllRes.Results[1], result)
+}
+
+func TestSearchResponse_UnknownObject(t *testing.T) {
+	data := `{"object":"list","results":[{"object":"page","id":"1"},{"object":"block","id":"2"}],"has_more":false}`
+
+	var response notion.SearchResponse
+	assert.Nil(t, json.Unmarshal([]byte(data), &response))
+	if assert.Len(t, response.Results, 2) {
+		assert.Equal(t, "1", response.Results[0].Page.ID)
+
+		unknown := response.Results[1]
+		assert.Equal(t, "block", unknown.Object)
+		assert.Nil(t, unknown.Page)
+		assert.Nil(t, unknown.Database)
+		encoded, err := json.Marshal(unknown)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"object":"block","id":"2"}`, string(encoded))
+	}
+}
@@ -1,6 +1,7 @@
 package version1
 
 import (
+	"context"
 	"github.com/dghubble/sling"
 	"net/http"
 )
@@ -37,12 +38,11 @@ type Person struct {
 	PersonEmail string `json:"email,omitempty"`
 }
 
-func (u *UserService) RetrieveUser(userID string) (*User, *http.Response, error) {
+func (u *UserService) RetrieveUser(ctx context.Context, userID string) (*User, *http.Response, error) {
 	user := new(User)
-	apiError := new(APIError)
-	resp, err := u.sling.New().Get(userID).Receive(user, apiError)
+	resp, err := receive(ctx, idempotent.named("users.retrieve").about(userID), u.sling.New().Get(userID), user)
 
-	return user, resp, relevantError(err, *apiError)
+	return user, resp, err
 }
 
 type ListUsersQueryParams struct {
@@ -60,10 +60,28 @@ type ListUsersResponse struct {
 // https://developers.notion.com/reference/get-users
 // See https://developers.notion.com/reference/pagination to understand
 // how to iterate through paginated responses
-func (u *UserService) ListUsers(params *ListUsersQueryParams) (*ListUsersResponse, *http.Response, error) {
+func (u *UserService) ListUsers(ctx context.Context, params *ListUsersQueryParams) (*ListUsersResponse, *http.Response, error) {
 	response := new(ListUsersResponse)
-	apiError := new(APIError)
-	resp, err := u.sling.New().Get("").QueryStruct(params).Receive(response, apiError)
+	resp, err := receive(ctx, idempotent.named("users.list"),
+		u.sling.New().Get("").QueryStruct(params), response)
 
-	return response, resp, relevantError(err, *apiError)
+	return response, resp, err
+}
+
+// ListIter returns a UserIter over every user in the workspace, starting at params.StartCursor.
+func (u *UserService) ListIter(ctx context.Context, params *ListUsersQueryParams) *UserIter {
+	query := ListUsersQueryParams{}
+	if params != nil {
+		query = *params
+	}
+
+	it := &UserIter{}
+	it.iterator = newIterator(ctx, query.StartCursor, query.PageSize,
+		func(ctx context.Context, cursor string, pageSize int32) (int, string, bool, error) {
+			query.StartCursor, query.PageSize = cursor, pageSize
+			response, _, err := u.ListUsers(ctx, &query)
+			it.results = response.Results
+			return len(it.results), response.NextCursor, response.HasMore, err
+		})
+	return it
 }
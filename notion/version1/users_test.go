@@ -1,6 +1,7 @@
 package version1_test
 
 import (
+	"context"
 	"fmt"
 	notion "github.com/oyekanmiayo/go-notion/notion/version1"
 	"github.com/stretchr/testify/assert"
@@ -50,7 +51,7 @@ func TestUserService_RetrieveUser(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Users.RetrieveUser("123")
+	resp, _, err := client.Users.RetrieveUser(context.Background(), "123")
 	assert.Nil(t, err)
 	assert.Equal(t, testUser, resp)
 }
@@ -66,7 +67,7 @@ func TestUserService_ListUsers(t *testing.T) {
 	})
 
 	client := notion.NewClient(httpClient, "0000")
-	resp, _, err := client.Users.ListUsers(&notion.ListUsersQueryParams{})
+	resp, _, err := client.Users.ListUsers(context.Background(), &notion.ListUsersQueryParams{})
 	assert.Nil(t, err)
 	assert.Equal(t, testListUsers, resp)
 }
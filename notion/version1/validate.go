@@ -0,0 +1,303 @@
+package version1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxFilterDepth is how many levels of compound filters Notion accepts: a compound filter can hold compound
+// filters, but those can only hold single filters.
+const maxFilterDepth = 2
+
+// filterConditions maps each database property type to the condition its filters use.
+var filterConditions = map[string]string{
+	"title":            "title",
+	"rich_text":        "rich_text",
+	"text":             "rich_text",
+	"url":              "url",
+	"email":            "email",
+	"phone_number":     "phone",
+	"number":           "number",
+	"checkbox":         "checkbox",
+	"select":           "select",
+	"multi_select":     "multi_select",
+	"date":             "date",
+	"created_time":     "created_time",
+	"last_edited_time": "last_edited_time",
+	"people":           "people",
+	"created_by":       "created_by",
+	"last_edited_by":   "last_edited_by",
+	"file":             "files",
+	"files":            "files",
+	"relation":         "relation",
+	"formula":          "formula",
+}
+
+var (
+	textOperators = []string{"equals", "does_not_equal", "contains", "does_not_contain", "starts_with", "ends_with",
+		"is_empty", "is_not_empty"}
+	numberOperators = []string{"equals", "does_not_equal", "greater_than", "less_than", "greater_than_or_equal_to",
+		"less_than_or_equal_to", "is_empty", "is_not_empty"}
+	dateOperators = []string{"equals", "before", "after", "on_or_before", "on_or_after", "is_empty", "is_not_empty",
+		"past_week", "past_month", "past_year", "next_week", "next_month", "next_year"}
+	peopleOperators = []string{"contains", "does_not_contain", "is_empty", "is_not_empty"}
+)
+
+// conditionOperators lists the operators each condition accepts.
+var conditionOperators = map[string][]string{
+	"title":            textOperators,
+	"rich_text":        textOperators,
+	"url":              textOperators,
+	"email":            textOperators,
+	"phone":            textOperators,
+	"number":           numberOperators,
+	"checkbox":         {"equals", "does_not_equal"},
+	"select":           {"equals", "does_not_equal", "is_empty", "is_not_empty"},
+	"multi_select":     {"contains", "does_not_contain", "is_empty", "is_not_empty"},
+	"date":             dateOperators,
+	"created_time":     dateOperators,
+	"last_edited_time": dateOperators,
+	"people":           peopleOperators,
+	"created_by":       peopleOperators,
+	"last_edited_by":   peopleOperators,
+	"files":            {"is_empty", "is_not_empty"},
+	"relation":         {"contains", "does_not_contain", "is_empty", "is_not_empty"},
+}
+
+// formulaConditions maps the conditions of a formula filter to the operators they accept.
+var formulaConditions = map[string][]string{
+	"text":     textOperators,
+	"checkbox": {"equals", "does_not_equal"},
+	"number":   numberOperators,
+	"date":     dateOperators,
+}
+
+// QueryProblem is one problem found by ValidateQuery.
+// Path locates the problem in the request body, e.g. "filter.and[1]" or "sorts[0]".
+type QueryProblem struct {
+	Path     string
+	Property string
+	Message  string
+}
+
+func (p QueryProblem) String() string {
+	return fmt.Sprintf("%v: %v", p.Path, p.Message)
+}
+
+// QueryValidationError holds every problem found by ValidateQuery.
+type QueryValidationError struct {
+	Problems []QueryProblem
+}
+
+func (e *QueryValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		problems[i] = problem.String()
+	}
+	return "invalid database query: " + strings.Join(problems, "; ")
+}
+
+// ValidateQuery checks the filter and sorts of params against db, as returned by RetrieveDatabase, without
+// making any request. It reports filters and sorts on unknown properties, conditions that don't match the type
+// of their property, unknown operators and compound filters nested too deeply.
+// If there are problems, the error is a *QueryValidationError listing all of them. db must not be nil.
+func ValidateQuery(db *Database, params *QueryDatabaseBodyParams) error {
+	if db == nil {
+		return errors.New("notion: ValidateQuery needs the database to check the query against")
+	}
+
+	v := &queryValidator{db: db}
+	if params != nil {
+		if params.Filter != nil {
+			v.validateFilter(params.Filter)
+		}
+		v.validateSorts(params.Sorts)
+	}
+
+	if len(v.problems) > 0 {
+		return &QueryValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type queryValidator struct {
+	db       *Database
+	problems []QueryProblem
+}
+
+func (v *queryValidator) report(path, property, format string, args ...interface{}) {
+	v.problems = append(v.problems, QueryProblem{Path: path, Property: property, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateFilter checks filter, which can be a SingleFilter, a CompoundFilter, a filter.Filter or anything that
+// marshals to a filter, by walking its JSON.
+func (v *queryValidator) validateFilter(filter interface{}) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		v.report("filter", "", "cannot be encoded: %v", err)
+		return
+	}
+
+	var tree interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		v.report("filter", "", "cannot be decoded: %v", err)
+		return
+	}
+
+	if tree != nil {
+		v.validateFilterNode("filter", tree, 0)
+	}
+}
+
+func (v *queryValidator) validateFilterNode(path string, node interface{}, depth int) {
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		v.report(path, "", "must be an object")
+		return
+	}
+
+	and, hasAnd := object["and"]
+	or, hasOr := object["or"]
+	switch {
+	case hasAnd && hasOr:
+		v.report(path, "", `cannot have both "and" and "or"`)
+	case hasAnd:
+		v.validateCompound(path+".and", and, depth+1)
+	case hasOr:
+		v.validateCompound(path+".or", or, depth+1)
+	default:
+		v.validateSingle(path, object)
+	}
+}
+
+func (v *queryValidator) validateCompound(path string, node interface{}, depth int) {
+	if depth > maxFilterDepth {
+		v.report(path, "", "compound filters can only be nested %d levels deep", maxFilterDepth)
+		return
+	}
+
+	filters, ok := node.([]interface{})
+	if !ok {
+		v.report(path, "", "must be a list of filters")
+		return
+	}
+
+	for i, filter := range filters {
+		v.validateFilterNode(fmt.Sprintf("%v[%d]", path, i), filter, depth)
+	}
+}
+
+func (v *queryValidator) validateSingle(path string, object map[string]interface{}) {
+	property, _ := object["property"].(string)
+	if property == "" {
+		v.report(path, "", "has no property")
+		return
+	}
+
+	var conditions []string
+	for key := range object {
+		if key != "property" {
+			conditions = append(conditions, key)
+		}
+	}
+	sort.Strings(conditions)
+	if len(conditions) != 1 {
+		v.report(path, property, "must have exactly one condition, has %d", len(conditions))
+		return
+	}
+	condition := conditions[0]
+
+	obj, ok := v.db.Properties[property]
+	if !ok {
+		v.report(path, property, "unknown property %q", property)
+		return
+	}
+
+	expected, ok := filterConditions[obj.Type]
+	if !ok {
+		v.report(path, property, "property %q of type %v cannot be filtered", property, obj.Type)
+		return
+	}
+	if condition != expected {
+		v.report(path, property, "property %q is a %v, but the filter uses a %v condition", property, obj.Type,
+			condition)
+		return
+	}
+
+	if condition == "formula" {
+		v.validateFormula(path+".formula", property, object[condition])
+		return
+	}
+	v.validateOperators(path+"."+condition, property, object[condition], conditionOperators[condition])
+}
+
+func (v *queryValidator) validateFormula(path, property string, node interface{}) {
+	object, ok := node.(map[string]interface{})
+	if !ok || len(object) != 1 {
+		v.report(path, property, "must have exactly one of text, checkbox, number or date")
+		return
+	}
+
+	for condition, operators := range object {
+		allowed, ok := formulaConditions[condition]
+		if !ok {
+			v.report(path, property, "unknown formula condition %q", condition)
+			return
+		}
+		v.validateOperators(path+"."+condition, property, operators, allowed)
+	}
+}
+
+func (v *queryValidator) validateOperators(path, property string, node interface{}, allowed []string) {
+	object, ok := node.(map[string]interface{})
+	if !ok || len(object) != 1 {
+		v.report(path, property, "must have exactly one operator")
+		return
+	}
+
+	for operator := range object {
+		if !contains(allowed, operator) {
+			v.report(path, property, "unknown operator %q", operator)
+		}
+	}
+}
+
+func (v *queryValidator) validateSorts(sorts []SortCriteria) {
+	for i, s := range sorts {
+		path := fmt.Sprintf("sorts[%d]", i)
+
+		switch {
+		case s.Property != "" && s.Timestamp != "":
+			v.report(path, s.Property, "cannot have both a property and a timestamp")
+		case s.Property != "":
+			if _, ok := v.db.Properties[s.Property]; !ok {
+				v.report(path, s.Property, "unknown property %q", s.Property)
+			}
+		case s.Timestamp != "":
+			if s.Timestamp != "created_time" && s.Timestamp != "last_edited_time" {
+				v.report(path, "", `timestamp must be "created_time" or "last_edited_time", not %q`, s.Timestamp)
+			}
+		default:
+			v.report(path, "", "must have a property or a timestamp")
+		}
+
+		if s.Direction != "" && s.Direction != "ascending" && s.Direction != "descending" {
+			v.report(path, s.Property, `direction must be "ascending" or "descending", not %q`, s.Direction)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package version1_test
+
+import (
+	"errors"
+	notion "github.com/oyekanmiayo/go-notion/notion/version1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testValidateDB = &notion.Database{
+	Object: "database",
+	ID:     "123",
+	Properties: map[string]notion.PropertyObj{
+		"Name":     {ID: "title", Type: "title"},
+		"Priority": {ID: "a1", Type: "number"},
+		"Done":     {ID: "a2", Type: "checkbox"},
+		"Due":      {ID: "a3", Type: "date"},
+		"Score":    {ID: "a4", Type: "formula"},
+		"Total":    {ID: "a5", Type: "rollup"},
+	},
+}
+
+func TestValidateQuery_Valid(t *testing.T) {
+	params := &notion.QueryDatabaseBodyParams{
+		Filter: &notion.CompoundFilter{
+			OR: []interface{}{
+				notion.SingleFilter{Property: "Name", Title: &notion.TextCondition{Contains: "x"}},
+				notion.CompoundFilter{
+					AND: []notion.SingleFilter{
+						{Property: "Done", Checkbox: &notion.CheckboxCondition{Equals: notion.Bool(false)}},
+						{Property: "Priority", Number: &notion.NumberCondition{GreaterThan: notion.Float64(2)}},
+					},
+				},
+			},
+		},
+		Sorts: []notion.SortCriteria{
+			{Property: "Due", Direction: "ascending"},
+			{Timestamp: "created_time", Direction: "descending"},
+		},
+	}
+
+	assert.Nil(t, notion.ValidateQuery(testValidateDB, params))
+	assert.Nil(t, notion.ValidateQuery(testValidateDB, &notion.QueryDatabaseBodyParams{}))
+}
+
+func TestValidateQuery_NilDatabase(t *testing.T) {
+	params := &notion.QueryDatabaseBodyParams{
+		Filter: notion.SingleFilter{Property: "Name", Title: &notion.TextCondition{Contains: "x"}},
+		Sorts:  []notion.SortCriteria{{Property: "Name", Direction: "ascending"}},
+	}
+
+	err := notion.ValidateQuery(nil, params)
+	assert.NotNil(t, err)
+	var validationErr *notion.QueryValidationError
+	assert.False(t, errors.As(err, &validationErr))
+	assert.NotNil(t, notion.ValidateQuery(nil, nil))
+}
+
+func TestValidateQuery_Filter(t *testing.T) {
+	params := &notion.QueryDatabaseBodyParams{
+		Filter: map[string]interface{}{
+			"and": []interface{}{
+				map[string]interface{}{"property": "Nmae", "title": map[string]interface{}{"contains": "x"}},
+				map[string]interface{}{"property": "Priority", "rich_text": map[string]interface{}{"contains": "2"}},
+				map[string]interface{}{"property": "Due", "date": map[string]interface{}{"yesterday": struct{}{}}},
+				map[string]interface{}{"property": "Score", "formula": map[string]interface{}{
+					"number": map[string]interface{}{"equals": 1},
+				}},
+				map[string]interface{}{"property": "Total", "number": map[string]interface{}{"equals": 1}},
+				map[string]interface{}{"or": []interface{}{
+					map[string]interface{}{"and": []interface{}{
+						map[string]interface{}{"property": "Done", "checkbox": map[string]interface{}{"equals": true}},
+					}},
+				}},
+			},
+		},
+	}
+
+	err := notion.ValidateQuery(testValidateDB, params)
+
+	var validationErr *notion.QueryValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []notion.QueryProblem{
+		{Path: "filter.and[0]", Property: "Nmae", Message: `unknown property "Nmae"`},
+		{Path: "filter.and[1]", Property: "Priority",
+			Message: `property "Priority" is a number, but the filter uses a rich_text condition`},
+		{Path: "filter.and[2].date", Property: "Due", Message: `unknown operator "yesterday"`},
+		{Path: "filter.and[4]", Property: "Total", Message: `property "Total" of type rollup cannot be filtered`},
+		{Path: "filter.and[5].or[0].and", Message: "compound filters can only be nested 2 levels deep"},
+	}, validationErr.Problems)
+}
+
+func TestValidateQuery_Sorts(t *testing.T) {
+	params := &notion.QueryDatabaseBodyParams{
+		Sorts: []notion.SortCriteria{
+			{Property: "Nmae"},
+			{Timestamp: "edited_time"},
+			{Property: "Due", Direction: "up"},
+			{},
+		},
+	}
+
+	err := notion.ValidateQuery(testValidateDB, params)
+	assert.Equal(t, `invalid database query: sorts[0]: unknown property "Nmae"; `+
+		`sorts[1]: timestamp must be "created_time" or "last_edited_time", not "edited_time"; `+
+		`sorts[2]: direction must be "ascending" or "descending", not "up"; `+
+		`sorts[3]: must have a property or a timestamp`, err.Error())
+}